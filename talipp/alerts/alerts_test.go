@@ -0,0 +1,242 @@
+package alerts
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/revanthstrakz/gotalipp/talipp/expr"
+	"github.com/revanthstrakz/gotalipp/talipp/ohlcv"
+	"github.com/stretchr/testify/assert"
+)
+
+// candleStart is the timestamp of the first test candle
+var candleStart = time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+// hourly builds hourly candles with the given closes
+func hourly(closes ...float64) []*ohlcv.OHLCV {
+	result := make([]*ohlcv.OHLCV, len(closes))
+	for i, close := range closes {
+		result[i] = ohlcv.NewOHLCV(candleStart.Add(time.Duration(i)*time.Hour), close, close+0.5, close-0.5, close, 100)
+	}
+	return result
+}
+
+// firedAt feeds candles to a new engine with one rule and returns the
+// indexes of the candles that fired
+func firedAt(t *testing.T, rule Rule, candles []*ohlcv.OHLCV) []int {
+	engine := NewEngine()
+	assert.NoError(t, engine.AddRule(rule))
+	result := make([]int, 0)
+	for i, candle := range candles {
+		if len(engine.AddCandle("BTC", "1h", candle)) > 0 {
+			result = append(result, i)
+		}
+	}
+	return result
+}
+
+func TestRules(t *testing.T) {
+	t.Run("Bars and deduplication", func(t *testing.T) {
+		rule := Rule{Name: "above", Condition: "close > 10", Bars: 3}
+		// Fires once per run of three bars above 10, not on every bar of the run
+		assert.Equal(t, []int{3, 8}, firedAt(t, rule, hourly(9, 11, 12, 13, 14, 9, 11, 12, 13)))
+	})
+
+	t.Run("Hysteresis", func(t *testing.T) {
+		closes := hourly(11, 9, 11, 7, 12)
+		assert.Equal(t, []int{0, 2, 4}, firedAt(t, Rule{Name: "above", Condition: "close > 10"}, closes))
+		// Dipping to 9 does not re-arm the rule, only going below 8 does
+		rule := Rule{Name: "above", Condition: "close > 10", ClearCondition: "close < 8"}
+		assert.Equal(t, []int{0, 4}, firedAt(t, rule, closes))
+	})
+
+	t.Run("Cooldown", func(t *testing.T) {
+		rule := Rule{Name: "above", Condition: "close > 10", Cooldown: 3 * time.Hour}
+		assert.Equal(t, []int{0, 3}, firedAt(t, rule, hourly(11, 9, 11, 11, 9, 11)))
+	})
+
+	t.Run("Duplicate candles", func(t *testing.T) {
+		engine := NewEngine()
+		assert.NoError(t, engine.AddRule(Rule{Name: "above", Condition: "close > 10", Bars: 2}))
+		candles := hourly(11, 12)
+		assert.Empty(t, engine.AddCandle("BTC", "1h", candles[0]))
+		// A replayed candle is not a second bar
+		assert.Empty(t, engine.AddCandle("BTC", "1h", candles[0]))
+		state, ok := engine.GetState("above", "BTC", "1h")
+		assert.True(t, ok)
+		assert.Equal(t, 1, state.Streak)
+		assert.Len(t, engine.AddCandle("BTC", "1h", candles[1]), 1)
+
+		state, _ = engine.GetState("above", "BTC", "1h")
+		assert.Equal(t, RuleState{Streak: 2, Active: true, LastFired: candles[1].Timestamp, Fired: 1, LastCandle: candles[1].Timestamp}, state)
+	})
+
+	t.Run("RSI", func(t *testing.T) {
+		engine := NewEngine()
+		assert.NoError(t, engine.AddRule(Rule{
+			Name:      "btc-overbought",
+			Symbol:    "BTC",
+			Timeframe: "1h",
+			Condition: "rsi(close, 14) > 70",
+			Value:     "rsi(close, 14)",
+			Bars:      3,
+		}))
+
+		closes := make([]float64, 30)
+		for i := range closes {
+			closes[i] = 100 + float64(i) + 2*math.Sin(float64(i))
+		}
+		alerts := make([]Alert, 0)
+		for _, candle := range hourly(closes...) {
+			alerts = append(alerts, engine.AddCandle("BTC", "1h", candle)...)
+		}
+
+		if assert.Len(t, alerts, 1) {
+			alert := alerts[0]
+			assert.Equal(t, "btc-overbought", alert.Rule)
+			assert.Equal(t, "rsi(close, 14) > 70 for 3 bars", alert.Message)
+			assert.Greater(t, alert.Value, 70.0)
+			// RSI(14) has its first value on bar 13, so the third bar above 70 is bar 15
+			assert.Equal(t, candleStart.Add(15*time.Hour), alert.Timestamp)
+		}
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		engine := NewEngine()
+		assert.Error(t, engine.AddRule(Rule{Condition: "close > 1"}))
+		assert.Error(t, engine.AddRule(Rule{Name: "negative", Condition: "close > 1", Bars: -1}))
+
+		err := engine.AddRule(Rule{Name: "typo", Condition: "rsi(clsoe) > 70"})
+		var parseError *expr.ParseError
+		assert.True(t, errors.As(err, &parseError))
+		assert.EqualError(t, engine.AddRule(Rule{Name: "typo", Condition: "close > 1", ClearCondition: "close <"}),
+			"rule typo: clear condition: unexpected end of expression at column 8")
+
+		assert.NoError(t, engine.AddRule(Rule{Name: "above", Condition: "close > 1"}))
+		assert.EqualError(t, engine.AddRule(Rule{Name: "above", Condition: "close > 2"}), "rule above already exists")
+
+		engine.AddCandle("BTC", "1h", hourly(2)[0])
+		assert.True(t, engine.RemoveRule("above"))
+		assert.False(t, engine.RemoveRule("above"))
+		_, ok := engine.GetState("above", "BTC", "1h")
+		assert.False(t, ok)
+	})
+}
+
+func TestEngineStreams(t *testing.T) {
+	received := make([]Alert, 0)
+	engine := NewEngine(WithNotifier(NotifierFunc(func(alert Alert) error {
+		received = append(received, alert)
+		return nil
+	})))
+	assert.NoError(t, engine.AddRule(Rule{Name: "btc-only", Symbol: "BTC", Timeframe: "1h", Condition: "close > 10"}))
+	assert.NoError(t, engine.AddRule(Rule{Name: "any", Condition: "close > 20"}))
+
+	btc := ohlcv.NewStream()
+	eth := ohlcv.NewStream()
+	engine.Watch("BTC", "1h", btc)
+	engine.Watch("ETH", "1h", eth)
+
+	for i, candle := range hourly(11, 21, 5) {
+		btc.Add(candle)
+		eth.Add(hourly(25, 30, 15)[i])
+	}
+
+	type fired struct{ rule, symbol string }
+	got := make([]fired, len(received))
+	for i, alert := range received {
+		got[i] = fired{alert.Rule, alert.Symbol}
+	}
+	assert.Equal(t, []fired{{"btc-only", "BTC"}, {"any", "ETH"}, {"any", "BTC"}}, got)
+
+	// Each stream has its own state for the same rule
+	btcState, _ := engine.GetState("any", "BTC", "1h")
+	ethState, _ := engine.GetState("any", "ETH", "1h")
+	assert.Equal(t, candleStart.Add(time.Hour), btcState.LastFired)
+	assert.Equal(t, candleStart, ethState.LastFired)
+
+	engine.Reset()
+	_, ok := engine.GetState("any", "BTC", "1h")
+	assert.False(t, ok)
+}
+
+func TestNotifiers(t *testing.T) {
+	alert := Alert{
+		Rule:      "btc-overbought",
+		Symbol:    "BTC",
+		Timeframe: "1h",
+		Timestamp: candleStart,
+		Value:     72.5,
+		Close:     101,
+		Message:   "rsi(close, 14) > 70 for 3 bars",
+	}
+
+	t.Run("Log", func(t *testing.T) {
+		var buffer bytes.Buffer
+		notifier := NewLogNotifier(log.New(&buffer, "", 0))
+		assert.NoError(t, notifier.Notify(alert))
+		assert.Equal(t, "2024-01-02T00:00:00Z BTC 1h btc-overbought: rsi(close, 14) > 70 for 3 bars (value 72.5)\n", buffer.String())
+		assert.NotNil(t, NewLogNotifier(nil))
+	})
+
+	t.Run("Webhook", func(t *testing.T) {
+		requests := make([]map[string]interface{}, 0)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+			body := make(map[string]interface{})
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			requests = append(requests, body)
+		}))
+		defer server.Close()
+
+		notifier := NewWebhookNotifier(server.URL, WithHeader("Authorization", "Bearer secret"), WithHTTPClient(server.Client()))
+		assert.NoError(t, notifier.Notify(alert))
+		withoutValue := alert
+		withoutValue.Value = math.NaN()
+		assert.NoError(t, notifier.Notify(withoutValue))
+
+		if assert.Len(t, requests, 2) {
+			assert.Equal(t, map[string]interface{}{
+				"rule":      "btc-overbought",
+				"symbol":    "BTC",
+				"timeframe": "1h",
+				"timestamp": "2024-01-02T00:00:00Z",
+				"value":     72.5,
+				"close":     101.0,
+				"message":   "rsi(close, 14) > 70 for 3 bars",
+			}, requests[0])
+			assert.NotContains(t, requests[1], "value")
+		}
+	})
+
+	t.Run("Webhook failure", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "down", http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		failures := make([]error, 0)
+		engine := NewEngine(
+			WithNotifier(NewWebhookNotifier(server.URL)),
+			WithErrorHandler(func(_ Alert, err error) {
+				failures = append(failures, err)
+			}),
+		)
+		assert.NoError(t, engine.AddRule(Rule{Name: "above", Condition: "close > 10"}))
+
+		// The alert is still returned when a notifier fails
+		assert.Len(t, engine.AddCandle("BTC", "1h", hourly(11)[0]), 1)
+		if assert.Len(t, failures, 1) {
+			assert.EqualError(t, failures[0], "webhook responded 500 Internal Server Error")
+		}
+	})
+}
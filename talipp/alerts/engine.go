@@ -0,0 +1,195 @@
+package alerts
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/revanthstrakz/gotalipp/talipp/ohlcv"
+)
+
+// Alert is a fired rule on a candle
+type Alert struct {
+	Rule      string    `json:"rule"`
+	Symbol    string    `json:"symbol"`
+	Timeframe string    `json:"timeframe"`
+	Timestamp time.Time `json:"timestamp"`
+	// Value is the value of the rule's Value expression, or NaN without one
+	Value   float64 `json:"-"`
+	Close   float64 `json:"close"`
+	Message string  `json:"message"`
+}
+
+// String returns a one-line description of the alert
+func (a Alert) String() string {
+	text := fmt.Sprintf("%s %s %s %s: %s", a.Timestamp.Format(time.RFC3339), a.Symbol, a.Timeframe, a.Rule, a.Message)
+	if !math.IsNaN(a.Value) {
+		text += fmt.Sprintf(" (value %g)", a.Value)
+	}
+	return text
+}
+
+// Engine evaluates alert rules on candles of one or more streams and sends
+// the alerts to its notifiers. It is safe for concurrent use, so streams can
+// be fed from different goroutines.
+type Engine struct {
+	mu        sync.Mutex
+	rules     []Rule
+	states    map[stateKey]*ruleState
+	notifiers []Notifier
+	onError   func(Alert, error)
+}
+
+// stateKey identifies the state of a rule on one stream
+type stateKey struct {
+	rule      string
+	symbol    string
+	timeframe string
+}
+
+// EngineOption configures optional Engine parameters
+type EngineOption func(*Engine)
+
+// WithNotifier adds a notifier that receives every alert
+func WithNotifier(notifier Notifier) EngineOption {
+	return func(e *Engine) {
+		e.notifiers = append(e.notifiers, notifier)
+	}
+}
+
+// WithErrorHandler sets the function called when a notifier fails (default
+// logs the error with the standard logger)
+func WithErrorHandler(handler func(Alert, error)) EngineOption {
+	return func(e *Engine) {
+		e.onError = handler
+	}
+}
+
+// NewEngine creates a new alert engine without rules
+func NewEngine(opts ...EngineOption) *Engine {
+	e := &Engine{
+		rules:     make([]Rule, 0),
+		states:    make(map[stateKey]*ruleState),
+		notifiers: make([]Notifier, 0),
+		onError: func(alert Alert, err error) {
+			log.Printf("alerts: notifying %s: %v", alert.Rule, err)
+		},
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// AddRule adds a rule after checking that its expressions parse
+func (e *Engine) AddRule(rule Rule) error {
+	if err := rule.validate(); err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, existing := range e.rules {
+		if existing.Name == rule.Name {
+			return fmt.Errorf("rule %s already exists", rule.Name)
+		}
+	}
+	e.rules = append(e.rules, rule)
+	return nil
+}
+
+// RemoveRule removes a rule and its state, reporting whether it existed
+func (e *Engine) RemoveRule(name string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for i, rule := range e.rules {
+		if rule.Name == name {
+			e.rules = append(e.rules[:i], e.rules[i+1:]...)
+			for key := range e.states {
+				if key.rule == name {
+					delete(e.states, key)
+				}
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// Watch evaluates the rules on every candle added to a stream
+func (e *Engine) Watch(symbol, timeframe string, stream *ohlcv.Stream) {
+	stream.Subscribe(func(candle *ohlcv.OHLCV) {
+		e.AddCandle(symbol, timeframe, candle)
+	})
+}
+
+// AddCandle evaluates the matching rules on a new candle of a stream, sends
+// the alerts to the notifiers and returns them
+func (e *Engine) AddCandle(symbol, timeframe string, candle *ohlcv.OHLCV) []Alert {
+	e.mu.Lock()
+	alerts := make([]Alert, 0)
+	for _, rule := range e.rules {
+		if !rule.matches(symbol, timeframe) {
+			continue
+		}
+
+		key := stateKey{rule: rule.Name, symbol: symbol, timeframe: timeframe}
+		state, ok := e.states[key]
+		if !ok {
+			// The rule was validated when added, so this cannot fail
+			state, _ = newRuleState(rule)
+			e.states[key] = state
+		}
+		if !state.addCandle(candle) {
+			continue
+		}
+
+		value := math.NaN()
+		if state.value != nil {
+			value = state.value.Value()
+		}
+		alerts = append(alerts, Alert{
+			Rule:      rule.Name,
+			Symbol:    symbol,
+			Timeframe: timeframe,
+			Timestamp: candle.Timestamp,
+			Value:     value,
+			Close:     candle.Close,
+			Message:   rule.describe(),
+		})
+	}
+	notifiers := append([]Notifier(nil), e.notifiers...)
+	e.mu.Unlock()
+
+	// Notify outside the lock so that slow notifiers do not block other streams
+	for _, alert := range alerts {
+		for _, notifier := range notifiers {
+			if err := notifier.Notify(alert); err != nil && e.onError != nil {
+				e.onError(alert, err)
+			}
+		}
+	}
+	return alerts
+}
+
+// GetState returns the state of a rule on a stream, if the rule has seen a
+// candle of that stream
+func (e *Engine) GetState(rule, symbol, timeframe string) (RuleState, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	state, ok := e.states[stateKey{rule: rule, symbol: symbol, timeframe: timeframe}]
+	if !ok {
+		return RuleState{}, false
+	}
+	return state.RuleState, true
+}
+
+// Reset clears the state of all rules, keeping the rules
+func (e *Engine) Reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.states = make(map[stateKey]*ruleState)
+}
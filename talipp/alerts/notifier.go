@@ -0,0 +1,127 @@
+package alerts
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"os"
+	"time"
+)
+
+// Notifier delivers alerts
+type Notifier interface {
+	// Notify delivers one alert
+	Notify(alert Alert) error
+}
+
+// NotifierFunc adapts a function to the Notifier interface
+type NotifierFunc func(Alert) error
+
+// Notify calls f(alert)
+func (f NotifierFunc) Notify(alert Alert) error {
+	return f(alert)
+}
+
+// LogNotifier writes every alert as one line to a logger
+type LogNotifier struct {
+	logger *log.Logger
+}
+
+// NewLogNotifier creates a new LogNotifier writing to logger, or to standard
+// output if logger is nil
+func NewLogNotifier(logger *log.Logger) *LogNotifier {
+	if logger == nil {
+		logger = log.New(os.Stdout, "", log.LstdFlags)
+	}
+	return &LogNotifier{logger: logger}
+}
+
+// Notify writes the alert to the logger
+func (n *LogNotifier) Notify(alert Alert) error {
+	n.logger.Println(alert.String())
+	return nil
+}
+
+// WebhookNotifier posts every alert as JSON to a URL
+type WebhookNotifier struct {
+	url     string
+	client  *http.Client
+	headers map[string]string
+}
+
+// WebhookOption configures optional WebhookNotifier parameters
+type WebhookOption func(*WebhookNotifier)
+
+// WithHTTPClient sets the HTTP client used to post alerts (default a client
+// with a 10 second timeout)
+func WithHTTPClient(client *http.Client) WebhookOption {
+	return func(n *WebhookNotifier) {
+		n.client = client
+	}
+}
+
+// WithHeader adds a header to every request, e.g. for authentication
+func WithHeader(name, value string) WebhookOption {
+	return func(n *WebhookNotifier) {
+		n.headers[name] = value
+	}
+}
+
+// NewWebhookNotifier creates a new WebhookNotifier posting to url
+func NewWebhookNotifier(url string, opts ...WebhookOption) *WebhookNotifier {
+	n := &WebhookNotifier{
+		url:     url,
+		client:  &http.Client{Timeout: 10 * time.Second},
+		headers: make(map[string]string),
+	}
+	for _, opt := range opts {
+		opt(n)
+	}
+
+	return n
+}
+
+// webhookPayload is the JSON body of a webhook request. The value is
+// omitted when the rule has no Value expression, as JSON has no NaN.
+type webhookPayload struct {
+	Alert
+	Value *float64 `json:"value,omitempty"`
+}
+
+// Notify posts the alert and fails unless the response status is 2xx
+func (n *WebhookNotifier) Notify(alert Alert) error {
+	payload := webhookPayload{Alert: alert}
+	if !math.IsNaN(alert.Value) {
+		payload.Value = &alert.Value
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequest(http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	for name, value := range n.headers {
+		request.Header.Set(name, value)
+	}
+
+	response, err := n.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	// Drain the body so that the connection can be reused
+	_, _ = io.Copy(io.Discard, response.Body)
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("webhook responded %s", response.Status)
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+// Package alerts evaluates alert rules continuously over candle streams and
+// delivers the alerts through pluggable notifiers
+package alerts
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/revanthstrakz/gotalipp/talipp/expr"
+	"github.com/revanthstrakz/gotalipp/talipp/ohlcv"
+)
+
+// Rule describes when an alert fires. For example "RSI(14) on BTC 1h > 70
+// for 3 bars" is
+//
+//	Rule{Name: "btc-overbought", Symbol: "BTC", Timeframe: "1h",
+//		Condition: "rsi(close, 14) > 70", Bars: 3}
+//
+// Once fired, a rule is latched and does not fire again until it is re-armed:
+// by default when Condition is false, or with hysteresis when ClearCondition
+// is true, e.g. "rsi(close, 14) < 65".
+type Rule struct {
+	// Name identifies the rule and must be unique within an Engine
+	Name string `json:"name"`
+	// Symbol and Timeframe select the streams the rule applies to; an empty
+	// value matches any stream. Each matching stream has its own rule state.
+	Symbol    string `json:"symbol,omitempty"`
+	Timeframe string `json:"timeframe,omitempty"`
+	// Condition is an expression (see package expr) that triggers the alert
+	Condition string `json:"condition"`
+	// ClearCondition is an optional expression that re-arms the rule
+	ClearCondition string `json:"clear_condition,omitempty"`
+	// Value is an optional expression reported with the alert, e.g. "rsi(close, 14)"
+	Value string `json:"value,omitempty"`
+	// Bars is the number of consecutive bars Condition must hold (default 1)
+	Bars int `json:"bars,omitempty"`
+	// Cooldown is the least time between two alerts of the same rule state,
+	// measured on candle timestamps
+	Cooldown time.Duration `json:"cooldown,omitempty"`
+	// Message is an optional text sent with the alert instead of a
+	// description of the rule
+	Message string `json:"message,omitempty"`
+}
+
+// matches returns whether the rule applies to a stream
+func (r Rule) matches(symbol, timeframe string) bool {
+	return (r.Symbol == "" || r.Symbol == symbol) && (r.Timeframe == "" || r.Timeframe == timeframe)
+}
+
+// describe returns the default message of an alert of the rule
+func (r Rule) describe() string {
+	if r.Message != "" {
+		return r.Message
+	}
+	if r.Bars > 1 {
+		return fmt.Sprintf("%s for %d bars", r.Condition, r.Bars)
+	}
+	return r.Condition
+}
+
+// validate checks the rule and that all its expressions parse
+func (r Rule) validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("rule name must not be empty")
+	}
+	if r.Bars < 0 {
+		return fmt.Errorf("rule %s: bars must not be negative", r.Name)
+	}
+	if r.Cooldown < 0 {
+		return fmt.Errorf("rule %s: cooldown must not be negative", r.Name)
+	}
+	_, err := newRuleState(r)
+	return err
+}
+
+// RuleState is the state of a rule on one stream
+type RuleState struct {
+	// Streak is the number of consecutive bars the condition has held
+	Streak int
+	// Active is true while the rule is latched after firing
+	Active bool
+	// LastFired is the candle timestamp of the latest alert
+	LastFired time.Time
+	// Fired is the number of alerts sent
+	Fired int
+	// LastCandle is the timestamp of the latest candle evaluated
+	LastCandle time.Time
+}
+
+// ruleState holds the expressions and state of a rule on one stream
+type ruleState struct {
+	RuleState
+	rule      Rule
+	condition *expr.Expression
+	clear     *expr.Expression
+	value     *expr.Expression
+	started   bool
+}
+
+// newRuleState parses the expressions of a rule
+func newRuleState(rule Rule) (*ruleState, error) {
+	state := &ruleState{rule: rule}
+	var err error
+	if state.condition, err = expr.Parse(rule.Condition); err != nil {
+		return nil, fmt.Errorf("rule %s: condition: %w", rule.Name, err)
+	}
+	if rule.ClearCondition != "" {
+		if state.clear, err = expr.Parse(rule.ClearCondition); err != nil {
+			return nil, fmt.Errorf("rule %s: clear condition: %w", rule.Name, err)
+		}
+	}
+	if rule.Value != "" {
+		if state.value, err = expr.Parse(rule.Value); err != nil {
+			return nil, fmt.Errorf("rule %s: value: %w", rule.Name, err)
+		}
+	}
+	return state, nil
+}
+
+// addCandle evaluates the rule on a new candle and reports whether it fires.
+// Candles that are not newer than the latest one are duplicates and ignored.
+func (s *ruleState) addCandle(candle *ohlcv.OHLCV) bool {
+	if s.started && !candle.Timestamp.After(s.LastCandle) {
+		return false
+	}
+	s.started = true
+	s.LastCandle = candle.Timestamp
+
+	// Every expression sees every candle to keep its indicators up to date
+	s.condition.AddCandle(candle)
+	if s.clear != nil {
+		s.clear.AddCandle(candle)
+	}
+	if s.value != nil {
+		s.value.AddCandle(candle)
+	}
+
+	triggered := s.condition.IsTrue()
+	if triggered {
+		s.Streak++
+	} else {
+		s.Streak = 0
+	}
+
+	if s.Active {
+		rearmed := !triggered
+		if s.clear != nil {
+			rearmed = s.clear.IsTrue()
+		}
+		if !rearmed {
+			return false
+		}
+		s.Active = false
+	}
+
+	bars := s.rule.Bars
+	if bars == 0 {
+		bars = 1
+	}
+	if s.Streak < bars {
+		return false
+	}
+	if s.Fired > 0 && candle.Timestamp.Before(s.LastFired.Add(s.rule.Cooldown)) {
+		return false
+	}
+
+	s.Active = true
+	s.LastFired = candle.Timestamp
+	s.Fired++
+	return true
+}
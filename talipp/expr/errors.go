@@ -0,0 +1,44 @@
+package expr
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ParseError describes an invalid expression and where the problem is
+type ParseError struct {
+	// Source is the expression being parsed
+	Source string
+	// Pos is the byte offset of the problem in Source
+	Pos int
+	// Message describes the problem
+	Message string
+}
+
+// newParseError creates a new ParseError
+func newParseError(source string, pos int, message string) *ParseError {
+	return &ParseError{Source: source, Pos: pos, Message: message}
+}
+
+// Error returns the message with the column of the problem, counted in
+// characters from 1
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s at column %d", e.Message, e.column()+1)
+}
+
+// Context returns the expression with a caret under the problem, e.g.
+//
+//	ema(close, 20) > sma(clsoe, 50)
+//	                     ^
+func (e *ParseError) Context() string {
+	return e.Source + "\n" + strings.Repeat(" ", e.column()) + "^"
+}
+
+// column returns the number of characters before the problem
+func (e *ParseError) column() int {
+	if e.Pos > len(e.Source) {
+		return utf8.RuneCountInString(e.Source)
+	}
+	return utf8.RuneCountInString(e.Source[:e.Pos])
+}
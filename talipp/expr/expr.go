@@ -0,0 +1,137 @@
+// Package expr evaluates formulas over candles, such as
+//
+//	ema(close, 20) - sma(close, 50) > atr(14) * 1.5
+//
+// An expression combines the candle fields open, high, low, close, volume,
+// hl2, hlc3 and ohlc4, numbers, indicator functions and the operators + - * /,
+// < <= > >= == !=, && || ! (or and, or, not). Comparisons and logical
+// operators give 1 for true and 0 for false. Indicator calls list their
+// series arguments first and their constant parameters after them, e.g.
+// ema(close, 20), rsi(close) or atr(14); see Function for adding more.
+//
+// Expressions are evaluated incrementally: every candle updates the
+// indicators inside the expression once. NaN stands for "no value yet" and
+// propagates through arithmetic and comparisons while indicators warm up.
+package expr
+
+import (
+	"math"
+
+	"github.com/revanthstrakz/gotalipp/talipp/indicators"
+	"github.com/revanthstrakz/gotalipp/talipp/ohlcv"
+)
+
+// Expression is a parsed expression with its own indicator state. It is an
+// indicator itself: its output starts on the first candle where the
+// expression has a value and then has one value per candle, which is NaN on
+// candles without a value, e.g. after a division by zero.
+type Expression struct {
+	*indicators.BaseIndicator
+	source string
+	root   node
+	value  float64
+}
+
+// Option configures optional Parse parameters
+type Option func(*parser)
+
+// WithFunction makes a function available in the expression under name,
+// replacing any builtin function of the same name
+func WithFunction(name string, function Function) Option {
+	return func(p *parser) {
+		p.functions[name] = function
+	}
+}
+
+// Parse parses an expression. Errors are of type *ParseError.
+func Parse(source string, opts ...Option) (*Expression, error) {
+	p := &parser{source: source, functions: make(map[string]Function, len(builtins))}
+	for name, function := range builtins {
+		p.functions[name] = function
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	tokens, err := tokenize(source)
+	if err != nil {
+		return nil, err
+	}
+	p.tokens = tokens
+	root, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Expression{
+		BaseIndicator: indicators.NewBaseIndicator("Expression"),
+		source:        source,
+		root:          root,
+		value:         math.NaN(),
+	}, nil
+}
+
+// MustParse is like Parse but panics on errors. It is meant for expressions
+// known to be valid, such as constants in code.
+func MustParse(source string, opts ...Option) *Expression {
+	e, err := Parse(source, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+// AddCandle evaluates the expression on a new candle
+func (e *Expression) AddCandle(candle *ohlcv.OHLCV) {
+	e.value = e.root.update(candle)
+	if e.IsInitialized() || !math.IsNaN(e.value) {
+		e.AddOutput(e.value)
+	}
+}
+
+// AddValue is not the preferred method for Expression, but can be used for
+// compatibility with the Indicator interface. It will use the value as open,
+// high, low and close with no volume.
+func (e *Expression) AddValue(value float64) {
+	e.AddCandle(&ohlcv.OHLCV{Open: value, High: value, Low: value, Close: value})
+}
+
+// Attach subscribes the expression to a stream so that it is evaluated on
+// every candle added to the stream
+func (e *Expression) Attach(stream *ohlcv.Stream) {
+	stream.Subscribe(e.AddCandle)
+}
+
+// Evaluate resets the expression and evaluates it on every candle. The
+// result has one value per candle, NaN where the expression has no value.
+func (e *Expression) Evaluate(candles []*ohlcv.OHLCV) []float64 {
+	e.Reset()
+	result := make([]float64, len(candles))
+	for i, candle := range candles {
+		e.AddCandle(candle)
+		result[i] = e.value
+	}
+	return result
+}
+
+// Value returns the value on the latest candle, NaN if there is none
+func (e *Expression) Value() float64 {
+	return e.value
+}
+
+// IsTrue returns whether the value on the latest candle is known and not 0
+func (e *Expression) IsTrue() bool {
+	return isTrue(e.value)
+}
+
+// String returns the source of the expression
+func (e *Expression) String() string {
+	return e.source
+}
+
+// Reset clears all values and indicator state in the Expression
+func (e *Expression) Reset() {
+	e.BaseIndicator.Reset()
+	e.root.reset()
+	e.value = math.NaN()
+}
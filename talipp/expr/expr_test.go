@@ -0,0 +1,259 @@
+package expr
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/revanthstrakz/gotalipp/talipp/indicators"
+	"github.com/revanthstrakz/gotalipp/talipp/ohlcv"
+	"github.com/stretchr/testify/assert"
+)
+
+// testCandles builds n deterministic candles oscillating around a slow trend
+// with a rally from bar 70
+func testCandles(n int) []*ohlcv.OHLCV {
+	start := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	result := make([]*ohlcv.OHLCV, n)
+	previous := 100.0
+	for i := 0; i < n; i++ {
+		close := 100 + 0.1*float64(i) + 5*math.Sin(float64(i)/4) + 2*math.Sin(float64(i)*1.7)
+		if i > 70 {
+			// A rally that pulls the fast averages away from the slow ones
+			close += float64(i - 70)
+		}
+		high := math.Max(previous, close) + 0.5 + 0.3*math.Abs(math.Cos(float64(i)))
+		low := math.Min(previous, close) - 0.5 - 0.2*math.Abs(math.Sin(float64(i)))
+		result[i] = ohlcv.NewOHLCV(start.Add(time.Duration(i)*time.Hour), previous, high, low, close, 1000+100*math.Abs(math.Sin(float64(i))))
+		previous = close
+	}
+	return result
+}
+
+// evaluate parses source and evaluates it on values used as closes
+func evaluate(t *testing.T, source string, values ...float64) []float64 {
+	e, err := Parse(source)
+	if !assert.NoError(t, err) {
+		return nil
+	}
+	result := make([]float64, 0, len(values))
+	for _, value := range values {
+		e.AddValue(value)
+		result = append(result, e.Value())
+	}
+	return result
+}
+
+func TestOperators(t *testing.T) {
+	tests := []struct {
+		source   string
+		expected float64
+	}{
+		{"1 + 2 * 3", 7},
+		{"(1 + 2) * 3", 9},
+		{"10 - 4 - 3", 3},
+		{"12 / 3 / 2", 2},
+		{"-2 * -3", 6},
+		{"- (1 + 2)", -3},
+		{"1.5e1 + .5", 15.5},
+		{"close * 2", 14},
+		{"close > 5 && close < 10", 1},
+		{"close > 5 and not (close > 6)", 0},
+		{"close < 5 || close >= 7", 1},
+		{"close == 7 or false", 1},
+		{"!(close != 7)", 1},
+		{"(2 < 1) == 0", 1},
+		{"hl2 + hlc3 + ohlc4", 21},
+	}
+	for _, test := range tests {
+		assert.Equal(t, []float64{test.expected}, evaluate(t, test.source, 7), test.source)
+	}
+
+	// Division by zero has no value
+	assert.True(t, math.IsNaN(evaluate(t, "close / (close - 7)", 7)[0]))
+}
+
+func TestUnknownValues(t *testing.T) {
+	// sma(close, 3) has no value on the first two bars
+	values := evaluate(t, "sma(close, 3) > 1", 1, 2, 3)
+	assert.True(t, math.IsNaN(values[0]))
+	assert.True(t, math.IsNaN(values[1]))
+	assert.Equal(t, 1.0, values[2])
+
+	// A false operand decides a && even if the other one is unknown
+	assert.Equal(t, []float64{0, 0, 0}, evaluate(t, "sma(close, 3) > 1 && close > 5", 1, 2, 3))
+	assert.Equal(t, []float64{1, 1, 1}, evaluate(t, "sma(close, 3) > 1 || close > 0", 1, 2, 3))
+	assert.True(t, math.IsNaN(evaluate(t, "!(sma(close, 3) > 1)", 1)[0]))
+}
+
+func TestIndicatorFunctions(t *testing.T) {
+	candles := testCandles(120)
+
+	t.Run("Readme formula", func(t *testing.T) {
+		e := MustParse("ema(close, 20) - sma(close, 50) > atr(14) * 1.5")
+		result := e.Evaluate(candles)
+
+		ema := indicators.NewEMA(20)
+		sma := indicators.NewSMA(50)
+		atr := indicators.NewATR(14)
+		trueCount := 0
+		for i, candle := range candles {
+			ema.AddValue(candle.Close)
+			sma.AddValue(candle.Close)
+			atr.AddOHLCValue(candle.High, candle.Low, candle.Close)
+			if !sma.IsInitialized() {
+				assert.True(t, math.IsNaN(result[i]), "bar %d", i)
+				continue
+			}
+			emaValue, _ := ema.GetLastValue()
+			smaValue, _ := sma.GetLastValue()
+			atrValue, _ := atr.GetLastValue()
+			expected := 0.0
+			if emaValue-smaValue > atrValue*1.5 {
+				expected = 1
+				trueCount++
+			}
+			assert.Equal(t, expected, result[i], "bar %d", i)
+		}
+		assert.Greater(t, trueCount, 0)
+
+		// The output starts on the first bar with a value
+		assert.Len(t, e.GetOutput(), len(candles)-49)
+	})
+
+	t.Run("Nested", func(t *testing.T) {
+		result := MustParse("sma(rsi(close, 5), 3)").Evaluate(candles)
+
+		rsi := indicators.NewRSI(5)
+		sma := indicators.NewSMA(3)
+		for i, candle := range candles {
+			rsi.AddValue(candle.Close)
+			expected := math.NaN()
+			if rsi.IsInitialized() {
+				value, _ := rsi.GetLastValue()
+				sma.AddValue(value)
+				if sma.IsInitialized() {
+					expected, _ = sma.GetLastValue()
+				}
+			}
+			if math.IsNaN(expected) {
+				assert.True(t, math.IsNaN(result[i]), "bar %d", i)
+			} else {
+				assert.InDelta(t, expected, result[i], 1e-9, "bar %d", i)
+			}
+		}
+	})
+
+	t.Run("Default parameters", func(t *testing.T) {
+		assert.Equal(t, replaceNaN(MustParse("rsi(close, 14)").Evaluate(candles)), replaceNaN(MustParse("rsi(close)").Evaluate(candles)))
+	})
+
+	t.Run("Helpers", func(t *testing.T) {
+		assert.Equal(t, []float64{-5, 2, 3}, evaluate(t, "min(close, 2) - max(0, 4 - close) + abs(-1)", -1, 3, 4))
+
+		prev := evaluate(t, "prev(close) + prev(close, 2)", 1, 2, 4)
+		assert.True(t, math.IsNaN(prev[1]))
+		assert.Equal(t, 3.0, prev[2])
+
+		crosses := evaluate(t, "crossover(close, 2) - crossunder(close, 2)", 1, 3, 3, 1, 2, 3)
+		assert.Equal(t, []float64{0, 1, 0, -1, 0, 1}, crosses)
+
+		// 0 / (close - 5) leaves a gap at 5, across which no cross is reported
+		gap := evaluate(t, "crossover(close + 0 / (close - 5), 3)", 1, 5, 4, 2, 4)
+		assert.Equal(t, 0.0, gap[0])
+		assert.True(t, math.IsNaN(gap[1]))
+		assert.Equal(t, []float64{0, 0, 1}, gap[2:])
+	})
+
+	t.Run("Streaming", func(t *testing.T) {
+		e := MustParse("crossover(ema(close, 5), sma(close, 10))")
+		stream := ohlcv.NewStream()
+		e.Attach(stream)
+		values := make([]float64, 0)
+		stream.Subscribe(func(*ohlcv.OHLCV) {
+			values = append(values, e.Value())
+		})
+		for _, candle := range candles {
+			stream.Add(candle)
+		}
+
+		batch := MustParse(e.String()).Evaluate(candles)
+		assert.Equal(t, replaceNaN(batch), replaceNaN(values))
+		assert.Contains(t, values, 1.0)
+
+		e.Reset()
+		assert.Empty(t, e.GetOutput())
+		assert.True(t, math.IsNaN(e.Value()))
+		assert.False(t, e.IsTrue())
+	})
+
+	t.Run("Custom function", func(t *testing.T) {
+		identity := SeriesIndicator(func(p []int) indicators.ValueIndicator {
+			return indicators.NewEMA(p[0])
+		}, 0, 1)
+		e, err := Parse("smooth(close) * 2", WithFunction("smooth", identity))
+		if assert.NoError(t, err) {
+			assert.InDelta(t, candles[0].Close*2, e.Evaluate(candles[:1])[0], 1e-9)
+		}
+	})
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		source  string
+		pos     int
+		message string
+	}{
+		{"", 0, "unexpected end of expression"},
+		{"close +", 7, "unexpected end of expression"},
+		{"ema(clsoe, 20)", 4, `unknown identifier "clsoe"`},
+		{"emaa(close, 20)", 0, `unknown function "emaa"`},
+		{"ema + 1", 0, `function "ema" must be called with parentheses`},
+		{"ema(close)", 0, "ema takes 2 arguments, got 1"},
+		{"kama(close, 1, 2, 3, 4)", 0, "kama takes 1 to 4 arguments, got 5"},
+		{"ema(close, 2.5)", 0, "invalid call of ema: parameter 1 must be an integer, got 2.5"},
+		{"ema(close, 0)", 0, "invalid call of ema: Window size must be greater than 0"},
+		{"ema(close, close)", 11, "parameter 1 of ema must be a constant number"},
+		{"(close > 1", 10, `expected ")"`},
+		{"close = 1", 6, `unexpected character '=', use "==" for equality`},
+		{"close & 1", 6, `unexpected character '&', use "&&"`},
+		{"close $ 1", 6, "unexpected character '$'"},
+		{"close € 1", 6, "unexpected character '€'"},
+		{"clôse > 1", 0, `unknown identifier "clôse"`},
+		{"1 < close < 2", 10, "comparisons cannot be chained"},
+		{"close 1", 6, `unexpected "1"`},
+		{"ema(close 20)", 10, `expected "," or ")" in call of ema, got "20"`},
+	}
+	for _, test := range tests {
+		_, err := Parse(test.source)
+		var parseError *ParseError
+		if assert.True(t, errors.As(err, &parseError), test.source) {
+			assert.Equal(t, test.pos, parseError.Pos, test.source)
+			assert.Contains(t, parseError.Message, test.message, test.source)
+		}
+	}
+
+	_, err := Parse("ema(close, 20) > sma(clsoe, 50)")
+	assert.EqualError(t, err, `unknown identifier "clsoe", expected one of close, high, hl2, hlc3, low, ohlc4, open, volume at column 22`)
+	assert.Equal(t, "ema(close, 20) > sma(clsoe, 50)\n                     ^", err.(*ParseError).Context())
+	assert.Panics(t, func() { MustParse("close +") })
+
+	// Columns and carets count characters, not bytes
+	_, err = Parse("clôse € 1")
+	assert.Equal(t, 7, err.(*ParseError).Pos)
+	assert.EqualError(t, err, "unexpected character '€' at column 7")
+	assert.Equal(t, "clôse € 1\n      ^", err.(*ParseError).Context())
+}
+
+// replaceNaN replaces NaN values with -1 so that results can be compared with assert.Equal
+func replaceNaN(values []float64) []float64 {
+	result := make([]float64, len(values))
+	for i, value := range values {
+		result[i] = value
+		if math.IsNaN(value) {
+			result[i] = -1
+		}
+	}
+	return result
+}
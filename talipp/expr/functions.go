@@ -0,0 +1,289 @@
+package expr
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/revanthstrakz/gotalipp/talipp/indicators"
+	"github.com/revanthstrakz/gotalipp/talipp/ohlcv"
+	"github.com/revanthstrakz/gotalipp/talipp/signals"
+)
+
+// Function describes a function that can be called in expressions, usually
+// an indicator. A call lists the series arguments first and the constant
+// parameters after them, as in ema(close, 20) or atr(14).
+type Function struct {
+	// Inputs is the number of series arguments. Functions without inputs,
+	// such as atr, read whole candles.
+	Inputs int
+	// Params are the default values of the constant parameters. A call can
+	// omit trailing parameters, but must give at least Required of them.
+	Params   []float64
+	Required int
+	// AcceptsNaN passes NaN inputs on to the updater instead of skipping the
+	// call, for functions that must notice gaps such as crossover
+	AcceptsNaN bool
+	// New creates the state of one call for the given parameters
+	New func(params []float64) (Updater, error)
+}
+
+// Updater computes one call of a function bar by bar. It receives the
+// candle of the bar and the values of the series arguments, which are never
+// NaN unless the function accepts them, and returns NaN while it has no value
+// yet.
+type Updater func(candle *ohlcv.OHLCV, inputs []float64) float64
+
+// SeriesIndicator returns a Function for an indicator fed with one series
+// argument, such as ema(close, 20). All parameters must be integers, like
+// window sizes.
+func SeriesIndicator(newIndicator func(params []int) indicators.ValueIndicator, required int, defaults ...float64) Function {
+	return Function{
+		Inputs:   1,
+		Params:   defaults,
+		Required: required,
+		New: func(params []float64) (Updater, error) {
+			ints, err := integers(params)
+			if err != nil {
+				return nil, err
+			}
+			indicator := newIndicator(ints)
+			return func(_ *ohlcv.OHLCV, inputs []float64) float64 {
+				indicator.AddValue(inputs[0])
+				return lastValue(indicator)
+			}, nil
+		},
+	}
+}
+
+// OHLCIndicator is an indicator computed from the high, low and close of every candle
+type OHLCIndicator interface {
+	indicators.ValueIndicator
+	AddOHLCValue(high, low, close float64)
+}
+
+// ohlcFunction returns a Function for an indicator fed with whole candles
+func ohlcFunction(newIndicator func(params []float64) (OHLCIndicator, error), required int, defaults ...float64) Function {
+	return Function{
+		Params:   defaults,
+		Required: required,
+		New: func(params []float64) (Updater, error) {
+			indicator, err := newIndicator(params)
+			if err != nil {
+				return nil, err
+			}
+			return func(candle *ohlcv.OHLCV, _ []float64) float64 {
+				indicator.AddOHLCValue(candle.High, candle.Low, candle.Close)
+				return lastValue(indicator)
+			}, nil
+		},
+	}
+}
+
+// candleFunction returns a Function for an indicator that needs the volume of every candle
+func candleFunction(newIndicator func(params []int) indicators.CandleIndicator, required int, defaults ...float64) Function {
+	return Function{
+		Params:   defaults,
+		Required: required,
+		New: func(params []float64) (Updater, error) {
+			ints, err := integers(params)
+			if err != nil {
+				return nil, err
+			}
+			indicator := newIndicator(ints)
+			values := indicator.(indicators.ValueIndicator)
+			return func(candle *ohlcv.OHLCV, _ []float64) float64 {
+				indicator.AddCandle(candle)
+				return lastValue(values)
+			}, nil
+		},
+	}
+}
+
+// windowOHLC adapts an OHLC indicator whose parameters are all integers
+func windowOHLC(newIndicator func(params []int) OHLCIndicator, required int, defaults ...float64) Function {
+	return ohlcFunction(func(params []float64) (OHLCIndicator, error) {
+		ints, err := integers(params)
+		if err != nil {
+			return nil, err
+		}
+		return newIndicator(ints), nil
+	}, required, defaults...)
+}
+
+// lastValue returns the latest output of an indicator, or NaN before it has one
+func lastValue(indicator indicators.ValueIndicator) float64 {
+	if !indicator.IsInitialized() {
+		return math.NaN()
+	}
+	value, err := indicator.GetLastValue()
+	if err != nil {
+		return math.NaN()
+	}
+	return value
+}
+
+// integers converts parameters that must be whole numbers
+func integers(params []float64) ([]int, error) {
+	result := make([]int, len(params))
+	for i, param := range params {
+		if param != math.Trunc(param) || math.IsInf(param, 0) {
+			return nil, fmt.Errorf("parameter %d must be an integer, got %g", i+1, param)
+		}
+		result[i] = int(param)
+	}
+	return result, nil
+}
+
+// builtins are the functions available in every expression
+var builtins = map[string]Function{
+	// Moving averages
+	"sma":      SeriesIndicator(func(p []int) indicators.ValueIndicator { return indicators.NewSMA(p[0]) }, 1, 0),
+	"ema":      SeriesIndicator(func(p []int) indicators.ValueIndicator { return indicators.NewEMA(p[0]) }, 1, 0),
+	"wma":      SeriesIndicator(func(p []int) indicators.ValueIndicator { return indicators.NewWMA(p[0]) }, 1, 0),
+	"dema":     SeriesIndicator(func(p []int) indicators.ValueIndicator { return indicators.NewDEMA(p[0]) }, 1, 0),
+	"tema":     SeriesIndicator(func(p []int) indicators.ValueIndicator { return indicators.NewTEMA(p[0]) }, 1, 0),
+	"hma":      SeriesIndicator(func(p []int) indicators.ValueIndicator { return indicators.NewHMA(p[0]) }, 1, 0),
+	"zlema":    SeriesIndicator(func(p []int) indicators.ValueIndicator { return indicators.NewZLEMA(p[0]) }, 1, 0),
+	"rma":      SeriesIndicator(func(p []int) indicators.ValueIndicator { return indicators.NewRMA(p[0]) }, 1, 0),
+	"mcginley": SeriesIndicator(func(p []int) indicators.ValueIndicator { return indicators.NewMcGinley(p[0]) }, 1, 0),
+	"kama": SeriesIndicator(func(p []int) indicators.ValueIndicator {
+		return indicators.NewKAMA(p[0], p[1], p[2])
+	}, 0, 10, 2, 30),
+	"vwma": {
+		Inputs:   1,
+		Params:   []float64{0},
+		Required: 1,
+		New: func(params []float64) (Updater, error) {
+			ints, err := integers(params)
+			if err != nil {
+				return nil, err
+			}
+			vwma := indicators.NewVWMA(ints[0])
+			return func(candle *ohlcv.OHLCV, inputs []float64) float64 {
+				vwma.AddValueVolume(inputs[0], candle.Volume)
+				return lastValue(vwma)
+			}, nil
+		},
+	},
+
+	// Oscillators and momentum
+	"rsi": SeriesIndicator(func(p []int) indicators.ValueIndicator { return indicators.NewRSI(p[0]) }, 0, 14),
+	"cmo": SeriesIndicator(func(p []int) indicators.ValueIndicator { return indicators.NewCMO(p[0]) }, 0, 14),
+	"roc": SeriesIndicator(func(p []int) indicators.ValueIndicator { return indicators.NewROC(p[0]) }, 0, 12),
+	"mom": SeriesIndicator(func(p []int) indicators.ValueIndicator { return indicators.NewMomentum(p[0]) }, 0, 10),
+	"trix": SeriesIndicator(func(p []int) indicators.ValueIndicator {
+		return indicators.NewTRIX(p[0])
+	}, 0, 15),
+	"tsi": SeriesIndicator(func(p []int) indicators.ValueIndicator {
+		return indicators.NewTSI(p[0], p[1])
+	}, 0, 25, 13),
+	"apo": SeriesIndicator(func(p []int) indicators.ValueIndicator {
+		return indicators.NewAPO(p[0], p[1])
+	}, 0, 12, 26),
+
+	// Rolling statistics
+	"stddev":      SeriesIndicator(func(p []int) indicators.ValueIndicator { return indicators.NewStdDev(p[0]) }, 1, 0),
+	"variance":    SeriesIndicator(func(p []int) indicators.ValueIndicator { return indicators.NewVariance(p[0]) }, 1, 0),
+	"zscore":      SeriesIndicator(func(p []int) indicators.ValueIndicator { return indicators.NewZScore(p[0]) }, 1, 0),
+	"median":      SeriesIndicator(func(p []int) indicators.ValueIndicator { return indicators.NewMedian(p[0]) }, 1, 0),
+	"percentrank": SeriesIndicator(func(p []int) indicators.ValueIndicator { return indicators.NewPercentRank(p[0]) }, 1, 0),
+	"highest":     SeriesIndicator(func(p []int) indicators.ValueIndicator { return indicators.NewRollingMax(p[0]) }, 1, 0),
+	"lowest":      SeriesIndicator(func(p []int) indicators.ValueIndicator { return indicators.NewRollingMin(p[0]) }, 1, 0),
+	"linreg":      SeriesIndicator(func(p []int) indicators.ValueIndicator { return indicators.NewLinReg(p[0]) }, 1, 0),
+	"slope":       SeriesIndicator(func(p []int) indicators.ValueIndicator { return indicators.NewLinRegSlope(p[0]) }, 1, 0),
+
+	// Candle indicators
+	"atr": windowOHLC(func(p []int) OHLCIndicator { return indicators.NewATR(p[0]) }, 0, 14),
+	"adx": windowOHLC(func(p []int) OHLCIndicator { return indicators.NewADX(p[0], p[1]) }, 0, 14, 14),
+	"cci": windowOHLC(func(p []int) OHLCIndicator { return indicators.NewCCI(p[0]) }, 0, 20),
+	"willr": windowOHLC(func(p []int) OHLCIndicator {
+		return indicators.NewWilliamsR(p[0])
+	}, 0, 14),
+	"chop": windowOHLC(func(p []int) OHLCIndicator {
+		return indicators.NewChoppiness(p[0])
+	}, 0, 14),
+	"supertrend": ohlcFunction(func(p []float64) (OHLCIndicator, error) {
+		ints, err := integers(p[:1])
+		if err != nil {
+			return nil, err
+		}
+		return indicators.NewSuperTrend(ints[0], p[1]), nil
+	}, 0, 10, 3),
+	"psar": ohlcFunction(func(p []float64) (OHLCIndicator, error) {
+		return indicators.NewPSAR(p[0], p[1], p[2]), nil
+	}, 0, 0.02, 0.02, 0.2),
+	"mfi": candleFunction(func(p []int) indicators.CandleIndicator { return indicators.NewMFI(p[0]) }, 0, 14),
+	"cmf": candleFunction(func(p []int) indicators.CandleIndicator { return indicators.NewCMF(p[0]) }, 0, 20),
+	"obv": candleFunction(func([]int) indicators.CandleIndicator { return indicators.NewOBV() }, 0),
+
+	// Helpers
+	"abs": {
+		Inputs: 1,
+		New: func([]float64) (Updater, error) {
+			return func(_ *ohlcv.OHLCV, inputs []float64) float64 { return math.Abs(inputs[0]) }, nil
+		},
+	},
+	"min": {
+		Inputs: 2,
+		New: func([]float64) (Updater, error) {
+			return func(_ *ohlcv.OHLCV, inputs []float64) float64 { return math.Min(inputs[0], inputs[1]) }, nil
+		},
+	},
+	"max": {
+		Inputs: 2,
+		New: func([]float64) (Updater, error) {
+			return func(_ *ohlcv.OHLCV, inputs []float64) float64 { return math.Max(inputs[0], inputs[1]) }, nil
+		},
+	},
+	"prev": {
+		Inputs: 1,
+		Params: []float64{1},
+		New: func(params []float64) (Updater, error) {
+			ints, err := integers(params)
+			if err != nil {
+				return nil, err
+			}
+			if ints[0] <= 0 {
+				return nil, fmt.Errorf("offset must be greater than 0")
+			}
+			history := make([]float64, 0, ints[0]+1)
+			return func(_ *ohlcv.OHLCV, inputs []float64) float64 {
+				history = append(history, inputs[0])
+				if len(history) <= ints[0] {
+					return math.NaN()
+				}
+				if len(history) > ints[0]+1 {
+					history = history[1:]
+				}
+				return history[0]
+			}, nil
+		},
+	},
+	"crossover":  crossFunction(signals.CrossAbove),
+	"crossunder": crossFunction(signals.CrossBelow),
+}
+
+// crossFunction returns a Function that is 1 on the bars where its first
+// input crosses its second one in the given direction and 0 otherwise. A NaN
+// input gives NaN and resets the cross, so no cross is reported across a gap.
+func crossFunction(eventType signals.EventType) Function {
+	return Function{
+		Inputs:     2,
+		AcceptsNaN: true,
+		New: func([]float64) (Updater, error) {
+			cross := signals.NewCross()
+			return func(_ *ohlcv.OHLCV, inputs []float64) float64 {
+				events := cross.AddPairValue(inputs[0], inputs[1])
+				if math.IsNaN(inputs[0]) || math.IsNaN(inputs[1]) {
+					return math.NaN()
+				}
+				for _, event := range events {
+					if event.Type == eventType {
+						return 1
+					}
+				}
+				return 0
+			}, nil
+		},
+	}
+}
@@ -0,0 +1,135 @@
+package expr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// tokenKind identifies the kind of a token
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenIdent
+	tokenOperator
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+// token is a lexical token with its byte position in the source
+type token struct {
+	kind  tokenKind
+	text  string
+	value float64
+	pos   int
+}
+
+// String returns the token as it is quoted in parse errors
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of expression"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// operators lists the operators, longest first so that "<=" wins over "<"
+var operators = []string{"&&", "||", "<=", ">=", "==", "!=", "+", "-", "*", "/", "<", ">", "!"}
+
+// keywordOperators maps the word forms of the logical operators onto their symbols
+var keywordOperators = map[string]string{"and": "&&", "or": "||", "not": "!"}
+
+// tokenize splits the source into tokens
+func tokenize(source string) ([]token, error) {
+	tokens := make([]token, 0)
+	i := 0
+	for i < len(source) {
+		c, size := utf8.DecodeRuneInString(source[i:])
+		switch {
+		case unicode.IsSpace(c):
+			i += size
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: i})
+			i++
+		case c == ',':
+			tokens = append(tokens, token{kind: tokenComma, text: ",", pos: i})
+			i++
+		case c < utf8.RuneSelf && isDigit(byte(c)) || c == '.':
+			start := i
+			for i < len(source) && (isDigit(source[i]) || source[i] == '.') {
+				i++
+			}
+			// Exponent, as in 1e-3
+			if i < len(source) && (source[i] == 'e' || source[i] == 'E') {
+				j := i + 1
+				if j < len(source) && (source[j] == '+' || source[j] == '-') {
+					j++
+				}
+				if j < len(source) && isDigit(source[j]) {
+					for j < len(source) && isDigit(source[j]) {
+						j++
+					}
+					i = j
+				}
+			}
+			text := source[start:i]
+			value, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return nil, newParseError(source, start, fmt.Sprintf("invalid number %q", text))
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: text, value: value, pos: start})
+		case c == '_' || unicode.IsLetter(c):
+			start := i
+			for i < len(source) {
+				r, size := utf8.DecodeRuneInString(source[i:])
+				if r != '_' && !unicode.IsLetter(r) && !(r < utf8.RuneSelf && isDigit(byte(r))) {
+					break
+				}
+				i += size
+			}
+			text := source[start:i]
+			if op, ok := keywordOperators[strings.ToLower(text)]; ok {
+				tokens = append(tokens, token{kind: tokenOperator, text: op, pos: start})
+			} else {
+				tokens = append(tokens, token{kind: tokenIdent, text: text, pos: start})
+			}
+		default:
+			op := matchOperator(source[i:])
+			if op == "" {
+				message := fmt.Sprintf("unexpected character %q", c)
+				if c == '=' {
+					message += `, use "==" for equality`
+				} else if c == '&' || c == '|' {
+					message += fmt.Sprintf(", use %q", string(c)+string(c))
+				}
+				return nil, newParseError(source, i, message)
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: op, pos: i})
+			i += len(op)
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(source)}), nil
+}
+
+// matchOperator returns the operator at the start of s, or "" if there is none
+func matchOperator(s string) string {
+	for _, op := range operators {
+		if strings.HasPrefix(s, op) {
+			return op
+		}
+	}
+	return ""
+}
+
+// isDigit returns whether b is an ASCII digit
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
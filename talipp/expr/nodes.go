@@ -0,0 +1,188 @@
+package expr
+
+import (
+	"math"
+
+	"github.com/revanthstrakz/gotalipp/talipp/ohlcv"
+)
+
+// node is a compiled part of an expression. Every node is updated exactly
+// once per candle, so the indicators inside it see every bar even when the
+// result of their branch does not matter.
+type node interface {
+	// update evaluates the node on a new candle; NaN means no value yet
+	update(candle *ohlcv.OHLCV) float64
+	// reset clears the state of the node and its children
+	reset()
+}
+
+// fields maps the candle field names onto their values
+var fields = map[string]func(*ohlcv.OHLCV) float64{
+	"open":   func(c *ohlcv.OHLCV) float64 { return c.Open },
+	"high":   func(c *ohlcv.OHLCV) float64 { return c.High },
+	"low":    func(c *ohlcv.OHLCV) float64 { return c.Low },
+	"close":  func(c *ohlcv.OHLCV) float64 { return c.Close },
+	"volume": func(c *ohlcv.OHLCV) float64 { return c.Volume },
+	"hl2":    func(c *ohlcv.OHLCV) float64 { return (c.High + c.Low) / 2.0 },
+	"hlc3":   func(c *ohlcv.OHLCV) float64 { return (c.High + c.Low + c.Close) / 3.0 },
+	"ohlc4":  func(c *ohlcv.OHLCV) float64 { return (c.Open + c.High + c.Low + c.Close) / 4.0 },
+}
+
+// constants maps the named constants onto their values
+var constants = map[string]float64{
+	"true":  1,
+	"false": 0,
+}
+
+// numberNode is a constant
+type numberNode struct {
+	value float64
+}
+
+func (n *numberNode) update(*ohlcv.OHLCV) float64 { return n.value }
+func (n *numberNode) reset()                      {}
+
+// fieldNode is a field of the current candle
+type fieldNode struct {
+	field func(*ohlcv.OHLCV) float64
+}
+
+func (n *fieldNode) update(candle *ohlcv.OHLCV) float64 { return n.field(candle) }
+func (n *fieldNode) reset()                             {}
+
+// unaryNode is a negation or a logical not
+type unaryNode struct {
+	op      string
+	operand node
+}
+
+func (n *unaryNode) update(candle *ohlcv.OHLCV) float64 {
+	value := n.operand.update(candle)
+	if n.op == "-" {
+		return -value
+	}
+	if math.IsNaN(value) {
+		return math.NaN()
+	}
+	return boolValue(value == 0)
+}
+
+func (n *unaryNode) reset() {
+	n.operand.reset()
+}
+
+// binaryNode is an arithmetic, comparison or logical operation
+type binaryNode struct {
+	op          string
+	left, right node
+}
+
+func (n *binaryNode) update(candle *ohlcv.OHLCV) float64 {
+	left := n.left.update(candle)
+	right := n.right.update(candle)
+
+	switch n.op {
+	case "&&":
+		// Three-valued logic: a false operand decides even if the other one is unknown
+		if isFalse(left) || isFalse(right) {
+			return 0
+		}
+		if math.IsNaN(left) || math.IsNaN(right) {
+			return math.NaN()
+		}
+		return 1
+	case "||":
+		if isTrue(left) || isTrue(right) {
+			return 1
+		}
+		if math.IsNaN(left) || math.IsNaN(right) {
+			return math.NaN()
+		}
+		return 0
+	}
+
+	if math.IsNaN(left) || math.IsNaN(right) {
+		return math.NaN()
+	}
+	switch n.op {
+	case "+":
+		return left + right
+	case "-":
+		return left - right
+	case "*":
+		return left * right
+	case "/":
+		if right == 0 {
+			return math.NaN()
+		}
+		return left / right
+	case "<":
+		return boolValue(left < right)
+	case "<=":
+		return boolValue(left <= right)
+	case ">":
+		return boolValue(left > right)
+	case ">=":
+		return boolValue(left >= right)
+	case "==":
+		return boolValue(left == right)
+	case "!=":
+		return boolValue(left != right)
+	}
+	return math.NaN()
+}
+
+func (n *binaryNode) reset() {
+	n.left.reset()
+	n.right.reset()
+}
+
+// callNode is a call of a Function with its own state
+type callNode struct {
+	function Function
+	params   []float64
+	args     []node
+	inputs   []float64
+	updater  Updater
+}
+
+func (n *callNode) update(candle *ohlcv.OHLCV) float64 {
+	ready := true
+	for i, arg := range n.args {
+		n.inputs[i] = arg.update(candle)
+		if math.IsNaN(n.inputs[i]) {
+			ready = false
+		}
+	}
+	// Series functions only start once all their inputs have values
+	if !ready && !n.function.AcceptsNaN {
+		return math.NaN()
+	}
+	return n.updater(candle, n.inputs)
+}
+
+func (n *callNode) reset() {
+	for _, arg := range n.args {
+		arg.reset()
+	}
+	// The parameters were validated when parsing, so this cannot fail
+	n.updater, _ = n.function.New(n.params)
+}
+
+// boolValue returns 1 for true and 0 for false
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// isTrue returns whether value is a known non-zero value
+func isTrue(value float64) bool {
+	return !math.IsNaN(value) && value != 0
+}
+
+// isFalse returns whether value is a known zero
+func isFalse(value float64) bool {
+	return value == 0
+}
@@ -0,0 +1,250 @@
+package expr
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// comparisons are the operators that cannot be chained, as in a < b < c
+var comparisons = map[string]bool{"<": true, "<=": true, ">": true, ">=": true, "==": true, "!=": true}
+
+// parser builds the node tree of an expression by recursive descent. From
+// the lowest to the highest precedence the levels are ||, &&, comparisons,
+// + and -, * and /, and the unary - and !.
+type parser struct {
+	source    string
+	tokens    []token
+	pos       int
+	functions map[string]Function
+}
+
+// parse parses a whole expression
+func (p *parser) parse() (node, error) {
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if next := p.peek(); next.kind != tokenEOF {
+		return nil, p.errorAt(next, fmt.Sprintf("unexpected %s", next))
+	}
+	return root, nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	return p.parseBinary(p.parseAnd, "||")
+}
+
+func (p *parser) parseAnd() (node, error) {
+	return p.parseBinary(p.parseComparison, "&&")
+}
+
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	op := p.peek()
+	if op.kind != tokenOperator || !comparisons[op.text] {
+		return left, nil
+	}
+	p.pos++
+	right, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	if next := p.peek(); next.kind == tokenOperator && comparisons[next.text] {
+		return nil, p.errorAt(next, "comparisons cannot be chained, combine them with &&")
+	}
+	return &binaryNode{op: op.text, left: left, right: right}, nil
+}
+
+func (p *parser) parseAdditive() (node, error) {
+	return p.parseBinary(p.parseMultiplicative, "+", "-")
+}
+
+func (p *parser) parseMultiplicative() (node, error) {
+	return p.parseBinary(p.parseUnary, "*", "/")
+}
+
+// parseBinary parses a left-associative chain of the given operators
+func (p *parser) parseBinary(operand func() (node, error), ops ...string) (node, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := p.peek()
+		if op.kind != tokenOperator || !contains(ops, op.text) {
+			return left, nil
+		}
+		p.pos++
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: op.text, left: left, right: right}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	op := p.peek()
+	if op.kind == tokenOperator && (op.text == "-" || op.text == "!") {
+		p.pos++
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		// Fold negative numbers so that they can be used as parameters
+		if number, ok := operand.(*numberNode); ok && op.text == "-" {
+			return &numberNode{value: -number.value}, nil
+		}
+		return &unaryNode{op: op.text, operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokenNumber:
+		return &numberNode{value: tok.value}, nil
+	case tokenLParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRParen {
+			return nil, p.errorAt(closing, fmt.Sprintf("expected \")\", got %s", closing))
+		}
+		return inner, nil
+	case tokenIdent:
+		if p.peek().kind == tokenLParen {
+			return p.parseCall(tok)
+		}
+		if field, ok := fields[tok.text]; ok {
+			return &fieldNode{field: field}, nil
+		}
+		if value, ok := constants[tok.text]; ok {
+			return &numberNode{value: value}, nil
+		}
+		if _, ok := p.functions[tok.text]; ok {
+			return nil, p.errorAt(tok, fmt.Sprintf("function %q must be called with parentheses", tok.text))
+		}
+		return nil, p.errorAt(tok, fmt.Sprintf("unknown identifier %q, expected one of %s", tok.text, fieldNames()))
+	case tokenEOF:
+		return nil, p.errorAt(tok, "unexpected end of expression")
+	default:
+		return nil, p.errorAt(tok, fmt.Sprintf("unexpected %s", tok))
+	}
+}
+
+// parseCall parses the arguments of a function call and creates its state
+func (p *parser) parseCall(name token) (node, error) {
+	function, ok := p.functions[name.text]
+	if !ok {
+		return nil, p.errorAt(name, fmt.Sprintf("unknown function %q", name.text))
+	}
+	p.pos++ // (
+
+	args := make([]node, 0)
+	argTokens := make([]token, 0)
+	if p.peek().kind != tokenRParen {
+		for {
+			argTokens = append(argTokens, p.peek())
+			arg, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if p.peek().kind != tokenComma {
+				break
+			}
+			p.pos++
+		}
+	}
+	if closing := p.next(); closing.kind != tokenRParen {
+		return nil, p.errorAt(closing, fmt.Sprintf("expected \",\" or \")\" in call of %s, got %s", name.text, closing))
+	}
+
+	minArgs, maxArgs := function.Inputs+function.Required, function.Inputs+len(function.Params)
+	if len(args) < minArgs || len(args) > maxArgs {
+		expected := fmt.Sprintf("%d", minArgs)
+		if maxArgs > minArgs {
+			expected = fmt.Sprintf("%d to %d", minArgs, maxArgs)
+		}
+		return nil, p.errorAt(name, fmt.Sprintf("%s takes %s arguments, got %d", name.text, expected, len(args)))
+	}
+
+	params := append([]float64(nil), function.Params...)
+	for i, arg := range args[function.Inputs:] {
+		number, ok := arg.(*numberNode)
+		if !ok {
+			return nil, p.errorAt(argTokens[function.Inputs+i], fmt.Sprintf("parameter %d of %s must be a constant number", i+1, name.text))
+		}
+		params[i] = number.value
+	}
+
+	updater, err := newUpdater(function, params)
+	if err != nil {
+		return nil, p.errorAt(name, fmt.Sprintf("invalid call of %s: %v", name.text, err))
+	}
+
+	return &callNode{
+		function: function,
+		params:   params,
+		args:     args[:function.Inputs],
+		inputs:   make([]float64, function.Inputs),
+		updater:  updater,
+	}, nil
+}
+
+// newUpdater creates the state of a call, turning constructor panics such
+// as an invalid window size into errors
+func newUpdater(function Function, params []float64) (updater Updater, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			updater, err = nil, fmt.Errorf("%v", r)
+		}
+	}()
+	return function.New(params)
+}
+
+// peek returns the next token without consuming it
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+// next consumes and returns the next token
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// errorAt returns a ParseError located at tok
+func (p *parser) errorAt(tok token, message string) error {
+	return newParseError(p.source, tok.pos, message)
+}
+
+// contains returns whether ops contains op
+func contains(ops []string, op string) bool {
+	for _, o := range ops {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldNames returns the candle field names in a stable order
+func fieldNames() string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,50 @@
+package indicators
+
+import (
+	"github.com/revanthstrakz/gotalipp/talipp/ohlcv"
+)
+
+// AccDist represents the Accumulation/Distribution Line indicator
+type AccDist struct {
+	*BaseIndicator
+	lastValue float64
+}
+
+// NewAccDist creates a new Accumulation/Distribution Line indicator
+func NewAccDist() *AccDist {
+	return &AccDist{
+		BaseIndicator: NewBaseIndicator("AccDist"),
+	}
+}
+
+// AddCandle adds a new candle to the Accumulation/Distribution calculation
+func (ad *AccDist) AddCandle(candle *ohlcv.OHLCV) {
+	ad.AddHLCVValue(candle.High, candle.Low, candle.Close, candle.Volume)
+}
+
+// AddHLCVValue adds a new high, low, close and volume to the Accumulation/Distribution calculation
+func (ad *AccDist) AddHLCVValue(high, low, close, volume float64) {
+	ad.lastValue += moneyFlowMultiplier(high, low, close) * volume
+	ad.AddOutput(ad.lastValue)
+}
+
+// AddValue is not the preferred method for AccDist, but can be used for compatibility
+// with the Indicator interface. It will use the value as high, low and close with a
+// volume of 1.
+func (ad *AccDist) AddValue(value float64) {
+	ad.AddHLCVValue(value, value, value, 1.0)
+}
+
+// Reset clears all values in the AccDist
+func (ad *AccDist) Reset() {
+	ad.BaseIndicator.Reset()
+	ad.lastValue = 0
+}
+
+// moneyFlowMultiplier returns the close location value ((C - L) - (H - C)) / (H - L)
+func moneyFlowMultiplier(high, low, close float64) float64 {
+	if high == low {
+		return 0
+	}
+	return ((close - low) - (high - close)) / (high - low)
+}
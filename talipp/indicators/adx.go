@@ -0,0 +1,174 @@
+package indicators
+
+import (
+	"math"
+)
+
+// ADX represents the Average Directional Index indicator together with the
+// Directional Movement Index (+DI and -DI)
+type ADX struct {
+	*BaseIndicator
+	diLength      int
+	adxSmoothing  int
+	prevHigh      float64
+	prevLow       float64
+	prevClose     float64
+	firstValueSet bool
+	trueRange     *RMA
+	plusDM        *RMA
+	minusDM       *RMA
+	dx            *RMA
+	plusDI        []float64
+	minusDI       []float64
+	adxValues     []float64
+}
+
+// ADXOutput represents the output of ADX calculations
+type ADXOutput struct {
+	ADX     float64
+	PlusDI  float64
+	MinusDI float64
+}
+
+// NewADX creates a new Average Directional Index indicator
+// diLength: the period for the +DI and -DI calculation (default 14)
+// adxSmoothing: the period for smoothing DX into ADX (default 14)
+func NewADX(diLength, adxSmoothing int) *ADX {
+	if diLength <= 0 || adxSmoothing <= 0 {
+		panic("All periods must be greater than 0")
+	}
+
+	return &ADX{
+		BaseIndicator: NewBaseIndicator("ADX"),
+		diLength:      diLength,
+		adxSmoothing:  adxSmoothing,
+		trueRange:     NewRMA(diLength),
+		plusDM:        NewRMA(diLength),
+		minusDM:       NewRMA(diLength),
+		dx:            NewRMA(adxSmoothing),
+		plusDI:        make([]float64, 0),
+		minusDI:       make([]float64, 0),
+		adxValues:     make([]float64, 0),
+	}
+}
+
+// AddOHLCValue adds a new OHLC candle data to the ADX calculation
+func (adx *ADX) AddOHLCValue(high, low, close float64) {
+	if !adx.firstValueSet {
+		// Directional movement needs a previous candle
+		adx.prevHigh = high
+		adx.prevLow = low
+		adx.prevClose = close
+		adx.firstValueSet = true
+		return
+	}
+
+	// Directional movement
+	upMove := high - adx.prevHigh
+	downMove := adx.prevLow - low
+	var plusDM, minusDM float64
+	if upMove > downMove && upMove > 0 {
+		plusDM = upMove
+	}
+	if downMove > upMove && downMove > 0 {
+		minusDM = downMove
+	}
+
+	// Wilder smoothing of the true range and directional movement
+	adx.trueRange.AddValue(calculateTrueRange(high, low, adx.prevClose))
+	adx.plusDM.AddValue(plusDM)
+	adx.minusDM.AddValue(minusDM)
+
+	adx.prevHigh = high
+	adx.prevLow = low
+	adx.prevClose = close
+
+	if !adx.trueRange.IsInitialized() {
+		return
+	}
+
+	// Calculate the directional indicators
+	smoothedTR, _ := adx.trueRange.GetLastValue()
+	smoothedPlusDM, _ := adx.plusDM.GetLastValue()
+	smoothedMinusDM, _ := adx.minusDM.GetLastValue()
+
+	var plusDI, minusDI float64
+	if smoothedTR != 0 {
+		plusDI = 100.0 * smoothedPlusDM / smoothedTR
+		minusDI = 100.0 * smoothedMinusDM / smoothedTR
+	}
+	adx.plusDI = append(adx.plusDI, plusDI)
+	adx.minusDI = append(adx.minusDI, minusDI)
+
+	// DX = 100 * |+DI - -DI| / (+DI + -DI)
+	var dx float64
+	if plusDI+minusDI != 0 {
+		dx = 100.0 * math.Abs(plusDI-minusDI) / (plusDI + minusDI)
+	}
+
+	adx.dx.AddValue(dx)
+	if adx.dx.IsInitialized() {
+		adxValue, _ := adx.dx.GetLastValue()
+		adx.adxValues = append(adx.adxValues, adxValue)
+		adx.AddOutput(adxValue)
+	}
+}
+
+// AddValue is not the preferred method for ADX, but can be used for compatibility
+// with the Indicator interface. It will use the value as high, low and close.
+func (adx *ADX) AddValue(value float64) {
+	adx.AddOHLCValue(value, value, value)
+}
+
+// GetWindowSize returns the +DI/-DI period of the ADX
+func (adx *ADX) GetWindowSize() int {
+	return adx.diLength
+}
+
+// Reset clears all values in the ADX
+func (adx *ADX) Reset() {
+	adx.BaseIndicator.Reset()
+	adx.trueRange.Reset()
+	adx.plusDM.Reset()
+	adx.minusDM.Reset()
+	adx.dx.Reset()
+	adx.prevHigh = 0.0
+	adx.prevLow = 0.0
+	adx.prevClose = 0.0
+	adx.firstValueSet = false
+	adx.plusDI = make([]float64, 0)
+	adx.minusDI = make([]float64, 0)
+	adx.adxValues = make([]float64, 0)
+}
+
+// GetADXOutput returns the complete ADX output (ADX, +DI, -DI) for every
+// candle that has an ADX value
+func (adx *ADX) GetADXOutput() []ADXOutput {
+	// The directional indicators start before the ADX
+	diStart := len(adx.plusDI) - len(adx.adxValues)
+
+	results := make([]ADXOutput, len(adx.adxValues))
+	for i := range adx.adxValues {
+		results[i] = ADXOutput{
+			ADX:     adx.adxValues[i],
+			PlusDI:  adx.plusDI[diStart+i],
+			MinusDI: adx.minusDI[diStart+i],
+		}
+	}
+
+	return results
+}
+
+// GetPlusDI returns just the +DI values
+func (adx *ADX) GetPlusDI() []float64 {
+	result := make([]float64, len(adx.plusDI))
+	copy(result, adx.plusDI)
+	return result
+}
+
+// GetMinusDI returns just the -DI values
+func (adx *ADX) GetMinusDI() []float64 {
+	result := make([]float64, len(adx.minusDI))
+	copy(result, adx.minusDI)
+	return result
+}
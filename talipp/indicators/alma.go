@@ -0,0 +1,71 @@
+package indicators
+
+import (
+	"math"
+)
+
+// ALMA represents an Arnaud Legoux Moving Average indicator
+type ALMA struct {
+	*BaseIndicator
+	windowSize int
+	weights    []float64
+	weightSum  float64
+	values     []float64
+}
+
+// NewALMA creates a new Arnaud Legoux Moving Average indicator
+// windowSize: the period of the moving average (default 9)
+// offset: position of the Gaussian peak within the window, 0 to 1 (default 0.85)
+// sigma: width of the Gaussian, a larger value gives a sharper filter (default 6)
+func NewALMA(windowSize int, offset, sigma float64) *ALMA {
+	if windowSize <= 0 {
+		panic("Window size must be greater than 0")
+	}
+	if sigma <= 0 {
+		panic("Sigma must be greater than 0")
+	}
+
+	m := offset * float64(windowSize-1)
+	s := float64(windowSize) / sigma
+	weights := make([]float64, windowSize)
+	var weightSum float64
+	for i := range weights {
+		weights[i] = math.Exp(-math.Pow(float64(i)-m, 2) / (2 * s * s))
+		weightSum += weights[i]
+	}
+
+	return &ALMA{
+		BaseIndicator: NewBaseIndicator("ALMA"),
+		windowSize:    windowSize,
+		weights:       weights,
+		weightSum:     weightSum,
+		values:        make([]float64, 0),
+	}
+}
+
+// AddValue adds a new value to the ALMA calculation
+func (alma *ALMA) AddValue(value float64) {
+	alma.values = append(alma.values, value)
+	if len(alma.values) > alma.windowSize {
+		alma.values = alma.values[1:]
+	}
+
+	if len(alma.values) == alma.windowSize {
+		var sum float64
+		for i, v := range alma.values {
+			sum += alma.weights[i] * v
+		}
+		alma.AddOutput(sum / alma.weightSum)
+	}
+}
+
+// GetWindowSize returns the window size of the ALMA
+func (alma *ALMA) GetWindowSize() int {
+	return alma.windowSize
+}
+
+// Reset clears all values in the ALMA
+func (alma *ALMA) Reset() {
+	alma.BaseIndicator.Reset()
+	alma.values = make([]float64, 0)
+}
@@ -0,0 +1,64 @@
+package indicators
+
+// APO represents the Absolute Price Oscillator indicator, the difference
+// between a fast and a slow moving average
+type APO struct {
+	*BaseIndicator
+	maType MAType
+	fastMA MovingAverage
+	slowMA MovingAverage
+}
+
+// APOOption configures optional APO parameters
+type APOOption func(*APO)
+
+// WithAPOMAType sets the moving average used for the fast and slow lines (default MATypeEMA)
+func WithAPOMAType(maType MAType) APOOption {
+	return func(apo *APO) {
+		apo.maType = maType
+	}
+}
+
+// NewAPO creates a new Absolute Price Oscillator indicator
+// fastLength: the period for the fast moving average (default 12)
+// slowLength: the period for the slow moving average (default 26)
+func NewAPO(fastLength, slowLength int, opts ...APOOption) *APO {
+	if fastLength <= 0 || slowLength <= 0 {
+		panic("All periods must be greater than 0")
+	}
+
+	if fastLength >= slowLength {
+		panic("Fast length must be less than slow length")
+	}
+
+	apo := &APO{
+		BaseIndicator: NewBaseIndicator("APO"),
+		maType:        MATypeEMA,
+	}
+	for _, opt := range opts {
+		opt(apo)
+	}
+	apo.fastMA = NewMovingAverage(apo.maType, fastLength)
+	apo.slowMA = NewMovingAverage(apo.maType, slowLength)
+
+	return apo
+}
+
+// AddValue adds a new value to the APO calculation
+func (apo *APO) AddValue(value float64) {
+	apo.fastMA.AddValue(value)
+	apo.slowMA.AddValue(value)
+
+	if apo.fastMA.IsInitialized() && apo.slowMA.IsInitialized() {
+		fastValue, _ := apo.fastMA.GetLastValue()
+		slowValue, _ := apo.slowMA.GetLastValue()
+		apo.AddOutput(fastValue - slowValue)
+	}
+}
+
+// Reset clears all values in the APO
+func (apo *APO) Reset() {
+	apo.BaseIndicator.Reset()
+	apo.fastMA.Reset()
+	apo.slowMA.Reset()
+}
@@ -0,0 +1,100 @@
+package indicators
+
+// Aroon represents the Aroon indicator, measuring how many periods have passed
+// since the highest high and the lowest low of the window
+type Aroon struct {
+	*BaseIndicator
+	windowSize int
+	extremes   *rollingExtremes
+	upValues   []float64
+	downValues []float64
+}
+
+// AroonOutput represents the output of Aroon calculations
+type AroonOutput struct {
+	Up         float64
+	Down       float64
+	Oscillator float64
+}
+
+// NewAroon creates a new Aroon indicator with specified window size (default 25)
+func NewAroon(windowSize int) *Aroon {
+	if windowSize <= 0 {
+		panic("Window size must be greater than 0")
+	}
+
+	return &Aroon{
+		BaseIndicator: NewBaseIndicator("Aroon"),
+		windowSize:    windowSize,
+		// The look-back covers the current bar and windowSize previous bars
+		extremes:   newRollingExtremes(windowSize + 1),
+		upValues:   make([]float64, 0),
+		downValues: make([]float64, 0),
+	}
+}
+
+// AddOHLCValue adds a new OHLC candle data to the Aroon calculation
+func (aroon *Aroon) AddOHLCValue(high, low, close float64) {
+	aroon.extremes.Add(high, low)
+	if !aroon.extremes.Full() {
+		return
+	}
+
+	// Aroon = 100 * (windowSize - periods since extreme) / windowSize
+	window := float64(aroon.windowSize)
+	up := 100.0 * (window - float64(aroon.extremes.MaxAge())) / window
+	down := 100.0 * (window - float64(aroon.extremes.MinAge())) / window
+
+	aroon.upValues = append(aroon.upValues, up)
+	aroon.downValues = append(aroon.downValues, down)
+
+	// The base indicator output is the Aroon Oscillator
+	aroon.AddOutput(up - down)
+}
+
+// AddValue is not the preferred method for Aroon, but can be used for compatibility
+// with the Indicator interface. It will use the value as high, low and close.
+func (aroon *Aroon) AddValue(value float64) {
+	aroon.AddOHLCValue(value, value, value)
+}
+
+// GetWindowSize returns the window size of the Aroon
+func (aroon *Aroon) GetWindowSize() int {
+	return aroon.windowSize
+}
+
+// Reset clears all values in the Aroon
+func (aroon *Aroon) Reset() {
+	aroon.BaseIndicator.Reset()
+	aroon.extremes.Reset()
+	aroon.upValues = make([]float64, 0)
+	aroon.downValues = make([]float64, 0)
+}
+
+// GetAroonOutput returns the complete Aroon output (Up, Down, Oscillator)
+func (aroon *Aroon) GetAroonOutput() []AroonOutput {
+	results := make([]AroonOutput, len(aroon.upValues))
+	for i := range aroon.upValues {
+		results[i] = AroonOutput{
+			Up:         aroon.upValues[i],
+			Down:       aroon.downValues[i],
+			Oscillator: aroon.upValues[i] - aroon.downValues[i],
+		}
+	}
+
+	return results
+}
+
+// GetAroonUp returns just the Aroon Up values
+func (aroon *Aroon) GetAroonUp() []float64 {
+	result := make([]float64, len(aroon.upValues))
+	copy(result, aroon.upValues)
+	return result
+}
+
+// GetAroonDown returns just the Aroon Down values
+func (aroon *Aroon) GetAroonDown() []float64 {
+	result := make([]float64, len(aroon.downValues))
+	copy(result, aroon.downValues)
+	return result
+}
@@ -7,73 +7,65 @@ import (
 // ATR represents an Average True Range indicator
 type ATR struct {
 	*BaseIndicator
-	windowSize     int
-	prevClose      float64
-	firstValueSet  bool
-	trueRanges     []float64
-	smoothedValue  float64
+	windowSize    int
+	maType        MAType
+	prevClose     float64
+	firstValueSet bool
+	ma            MovingAverage
+}
+
+// ATROption configures optional ATR parameters
+type ATROption func(*ATR)
+
+// WithATRMAType sets the moving average used to smooth the true range
+// (default Wilder's smoothing, MATypeRMA)
+func WithATRMAType(maType MAType) ATROption {
+	return func(atr *ATR) {
+		atr.maType = maType
+	}
 }
 
 // NewATR creates a new Average True Range indicator with specified window size
-func NewATR(windowSize int) *ATR {
+func NewATR(windowSize int, opts ...ATROption) *ATR {
 	if windowSize <= 0 {
 		panic("Window size must be greater than 0")
 	}
 
-	return &ATR{
+	atr := &ATR{
 		BaseIndicator: NewBaseIndicator("ATR"),
 		windowSize:    windowSize,
+		maType:        MATypeRMA,
 		prevClose:     0.0,
 		firstValueSet: false,
-		trueRanges:    make([]float64, 0),
-		smoothedValue: 0.0,
 	}
+	for _, opt := range opts {
+		opt(atr)
+	}
+	atr.ma = NewMovingAverage(atr.maType, windowSize)
+
+	return atr
 }
 
 // AddOHLCValue adds a new OHLC candle data to the ATR calculation
 func (atr *ATR) AddOHLCValue(high, low, close float64) {
 	var trueRange float64
-	
+
 	if !atr.firstValueSet {
 		// For the first value, true range is simply High - Low
 		trueRange = high - low
-		atr.prevClose = close
 		atr.firstValueSet = true
 	} else {
-		// Calculate the true range
-		tr1 := high - low                           // Current high - current low
-		tr2 := math.Abs(high - atr.prevClose)       // Current high - previous close
-		tr3 := math.Abs(low - atr.prevClose)        // Current low - previous close
-		
-		// True range is the maximum of the three
-		trueRange = math.Max(tr1, math.Max(tr2, tr3))
-		
-		// Update previous close
-		atr.prevClose = close
+		trueRange = calculateTrueRange(high, low, atr.prevClose)
 	}
-	
-	// Store the true range
-	atr.trueRanges = append(atr.trueRanges, trueRange)
-	
-	// Calculate ATR
-	if len(atr.trueRanges) == atr.windowSize {
-		// For the first complete window, calculate a simple average
-		var sum float64
-		for _, tr := range atr.trueRanges {
-			sum += tr
-		}
-		atr.smoothedValue = sum / float64(atr.windowSize)
-		atr.AddOutput(atr.smoothedValue)
-		
-		// Remove the oldest true range
-		atr.trueRanges = atr.trueRanges[1:]
-	} else if len(atr.trueRanges) > atr.windowSize {
-		// For subsequent values, use smoothed method
-		atr.smoothedValue = ((atr.smoothedValue * float64(atr.windowSize-1)) + trueRange) / float64(atr.windowSize)
-		atr.AddOutput(atr.smoothedValue)
-		
-		// Remove the oldest true range
-		atr.trueRanges = atr.trueRanges[1:]
+
+	// Update previous close
+	atr.prevClose = close
+
+	// Smooth the true range
+	atr.ma.AddValue(trueRange)
+	if atr.ma.IsInitialized() {
+		value, _ := atr.ma.GetLastValue()
+		atr.AddOutput(value)
 	}
 }
 
@@ -87,3 +79,21 @@ func (atr *ATR) AddValue(value float64) {
 func (atr *ATR) GetWindowSize() int {
 	return atr.windowSize
 }
+
+// Reset clears all values in the ATR
+func (atr *ATR) Reset() {
+	atr.BaseIndicator.Reset()
+	atr.ma.Reset()
+	atr.prevClose = 0.0
+	atr.firstValueSet = false
+}
+
+// calculateTrueRange returns the true range of a candle given the previous close
+func calculateTrueRange(high, low, prevClose float64) float64 {
+	tr1 := high - low                 // Current high - current low
+	tr2 := math.Abs(high - prevClose) // Current high - previous close
+	tr3 := math.Abs(low - prevClose)  // Current low - previous close
+
+	// True range is the maximum of the three
+	return math.Max(tr1, math.Max(tr2, tr3))
+}
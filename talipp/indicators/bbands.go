@@ -1,19 +1,16 @@
 package indicators
 
-import (
-	"math"
-)
-
 // BBands represents Bollinger Bands indicator
 type BBands struct {
 	*BaseIndicator
+	channel
 	windowSize      int
 	deviationFactor float64
-	sma             *SMA
-	prices          []float64
-	upperBands      []float64
-	middleBands     []float64
-	lowerBands      []float64
+	maType          MAType
+	ma              MovingAverage
+	stats           *rollingStats
+	percentB        []float64
+	bandwidth       []float64
 }
 
 // BBandsOutput represents the output of Bollinger Bands calculations
@@ -23,62 +20,83 @@ type BBandsOutput struct {
 	Lower  float64
 }
 
+// BBandsOption configures optional Bollinger Bands parameters
+type BBandsOption func(*BBands)
+
+// WithBBandsMAType sets the moving average used for the middle band
+// (default MATypeSMA). The band width is always the population standard
+// deviation of the window.
+func WithBBandsMAType(maType MAType) BBandsOption {
+	return func(bb *BBands) {
+		bb.maType = maType
+	}
+}
+
 // NewBBands creates a new Bollinger Bands indicator
 // windowSize: the period for the SMA (default 20)
 // deviationFactor: the standard deviation factor (default 2.0)
-func NewBBands(windowSize int, deviationFactor float64) *BBands {
+func NewBBands(windowSize int, deviationFactor float64, opts ...BBandsOption) *BBands {
 	if windowSize <= 0 {
 		panic("Window size must be greater than 0")
 	}
 
-	return &BBands{
+	bb := &BBands{
 		BaseIndicator:   NewBaseIndicator("BBands"),
 		windowSize:      windowSize,
 		deviationFactor: deviationFactor,
-		sma:             NewSMA(windowSize),
-		prices:          make([]float64, 0),
-		upperBands:      make([]float64, 0),
-		middleBands:     make([]float64, 0),
-		lowerBands:      make([]float64, 0),
+		maType:          MATypeSMA,
+		stats:           newRollingStats(windowSize),
+		channel:         newChannel(),
+		percentB:        make([]float64, 0),
+		bandwidth:       make([]float64, 0),
+	}
+	for _, opt := range opts {
+		opt(bb)
 	}
+	bb.ma = NewMovingAverage(bb.maType, windowSize)
+
+	return bb
 }
 
 // AddValue adds a new value to the Bollinger Bands calculation
 func (bb *BBands) AddValue(value float64) {
 	bb.input = append(bb.input, value)
-	bb.prices = append(bb.prices, value)
-	
-	// Keep only the last windowSize values
-	if len(bb.prices) > bb.windowSize {
-		bb.prices = bb.prices[1:]
-	}
-	
-	// Add value to SMA
-	bb.sma.AddValue(value)
-	
+	bb.stats.Add(value)
+
+	// Add value to the middle band moving average
+	bb.ma.AddValue(value)
+
 	// If we have enough values, calculate bands
-	if len(bb.prices) == bb.windowSize && bb.sma.IsInitialized() {
-		// Get latest SMA value
-		smaValue, _ := bb.sma.GetLastValue()
-		
-		// Calculate standard deviation
-		var sum float64
-		for _, price := range bb.prices {
-			sum += math.Pow(price-smaValue, 2)
-		}
-		stdDev := math.Sqrt(sum / float64(bb.windowSize))
-		
+	if bb.stats.Full() && bb.ma.IsInitialized() {
+		// Get latest middle band value
+		maValue, _ := bb.ma.GetLastValue()
+
+		// Population standard deviation of the window
+		stdDev := bb.stats.StdDev()
+
 		// Calculate bands
-		upperBand := smaValue + (bb.deviationFactor * stdDev)
-		lowerBand := smaValue - (bb.deviationFactor * stdDev)
-		
+		upperBand := maValue + (bb.deviationFactor * stdDev)
+		lowerBand := maValue - (bb.deviationFactor * stdDev)
+
 		// Store band values
-		bb.upperBands = append(bb.upperBands, upperBand)
-		bb.middleBands = append(bb.middleBands, smaValue)
-		bb.lowerBands = append(bb.lowerBands, lowerBand)
-		
+		bb.addBands(upperBand, maValue, lowerBand)
+
+		// %B = (price - lower) / (upper - lower), 0.5 when the bands collapse
+		percentB := 0.5
+		if upperBand != lowerBand {
+			percentB = (value - lowerBand) / (upperBand - lowerBand)
+		}
+		bb.percentB = append(bb.percentB, percentB)
+
+		// Bandwidth = (upper - lower) / middle
+		var bandwidth float64
+		if maValue != 0 {
+			bandwidth = (upperBand - lowerBand) / maValue
+		}
+		bb.bandwidth = append(bb.bandwidth, bandwidth)
+
 		// Add output - using middle band as the output value for the base indicator
-		bb.AddOutput(smaValue)
+		bb.AddOutput(maValue)
 	}
 }
 
@@ -87,48 +105,31 @@ func (bb *BBands) GetWindowSize() int {
 	return bb.windowSize
 }
 
-// GetBBandsOutput returns the complete Bollinger Bands output (Upper, Middle, Lower)
-func (bb *BBands) GetBBandsOutput() []BBandsOutput {
-	results := make([]BBandsOutput, 0)
-	
-	// Get the minimum length of the three slices
-	minLength := len(bb.upperBands)
-	if len(bb.middleBands) < minLength {
-		minLength = len(bb.middleBands)
-	}
-	if len(bb.lowerBands) < minLength {
-		minLength = len(bb.lowerBands)
-	}
-	
-	// Build the output
-	for i := 0; i < minLength; i++ {
-		results = append(results, BBandsOutput{
-			Upper:  bb.upperBands[i],
-			Middle: bb.middleBands[i],
-			Lower:  bb.lowerBands[i],
-		})
-	}
-	
-	return results
+// Reset clears all values in the Bollinger Bands
+func (bb *BBands) Reset() {
+	bb.BaseIndicator.Reset()
+	bb.ma.Reset()
+	bb.stats.Reset()
+	bb.resetBands()
+	bb.percentB = make([]float64, 0)
+	bb.bandwidth = make([]float64, 0)
 }
 
-// GetUpperBand returns just the upper band values
-func (bb *BBands) GetUpperBand() []float64 {
-	result := make([]float64, len(bb.upperBands))
-	copy(result, bb.upperBands)
-	return result
+// GetBBandsOutput returns the complete Bollinger Bands output (Upper, Middle, Lower)
+func (bb *BBands) GetBBandsOutput() []BBandsOutput {
+	return bb.GetChannelOutput()
 }
 
-// GetMiddleBand returns just the middle band values
-func (bb *BBands) GetMiddleBand() []float64 {
-	result := make([]float64, len(bb.middleBands))
-	copy(result, bb.middleBands)
+// GetPercentB returns the %B values, the position of the price within the bands
+func (bb *BBands) GetPercentB() []float64 {
+	result := make([]float64, len(bb.percentB))
+	copy(result, bb.percentB)
 	return result
 }
 
-// GetLowerBand returns just the lower band values
-func (bb *BBands) GetLowerBand() []float64 {
-	result := make([]float64, len(bb.lowerBands))
-	copy(result, bb.lowerBands)
+// GetBandwidth returns the bandwidth values, the band width relative to the middle band
+func (bb *BBands) GetBandwidth() []float64 {
+	result := make([]float64, len(bb.bandwidth))
+	copy(result, bb.bandwidth)
 	return result
 }
@@ -0,0 +1,46 @@
+package indicators
+
+// Beta represents the rolling beta of an asset against a benchmark: the
+// covariance of the two series divided by the variance of the benchmark.
+//
+// Beta is usually measured on returns rather than prices, so feed the
+// indicator with the returns of both series (for example the outputs of two
+// ROC(1) indicators) unless the beta of the raw values is wanted.
+type Beta struct {
+	*BaseIndicator
+	windowSize int
+	covariance *rollingCovariance
+}
+
+// NewBeta creates a new rolling beta indicator with specified window size (default 20)
+func NewBeta(windowSize int) *Beta {
+	return &Beta{
+		BaseIndicator: NewBaseIndicator("Beta"),
+		windowSize:    windowSize,
+		covariance:    newRollingCovariance(windowSize),
+	}
+}
+
+// AddPairValue adds the next value of the asset and of the benchmark to the beta calculation
+func (beta *Beta) AddPairValue(asset, benchmark float64) {
+	beta.covariance.Add(asset, benchmark)
+
+	if beta.covariance.Full() {
+		var value float64 // 0 when the benchmark is constant over the window
+		if variance := beta.covariance.second.Variance(); variance != 0 {
+			value = beta.covariance.Covariance() / variance
+		}
+		beta.AddOutput(value)
+	}
+}
+
+// GetWindowSize returns the window size of the Beta
+func (beta *Beta) GetWindowSize() int {
+	return beta.windowSize
+}
+
+// Reset clears all values in the Beta
+func (beta *Beta) Reset() {
+	beta.BaseIndicator.Reset()
+	beta.covariance.Reset()
+}
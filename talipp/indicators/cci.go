@@ -0,0 +1,66 @@
+package indicators
+
+import (
+	"math"
+)
+
+// CCI represents the Commodity Channel Index indicator
+type CCI struct {
+	*BaseIndicator
+	windowSize int
+	typical    *rollingStats
+}
+
+// NewCCI creates a new Commodity Channel Index indicator with specified window size (default 20)
+func NewCCI(windowSize int) *CCI {
+	if windowSize <= 0 {
+		panic("Window size must be greater than 0")
+	}
+
+	return &CCI{
+		BaseIndicator: NewBaseIndicator("CCI"),
+		windowSize:    windowSize,
+		typical:       newRollingStats(windowSize),
+	}
+}
+
+// AddOHLCValue adds a new OHLC candle data to the CCI calculation
+func (cci *CCI) AddOHLCValue(high, low, close float64) {
+	typical := (high + low + close) / 3.0
+	cci.typical.Add(typical)
+	if !cci.typical.Full() {
+		return
+	}
+
+	// Mean absolute deviation of the typical price from its average
+	mean := cci.typical.Mean()
+	var meanDeviation float64
+	for i := 0; i < cci.windowSize; i++ {
+		meanDeviation += math.Abs(cci.typical.At(i) - mean)
+	}
+	meanDeviation /= float64(cci.windowSize)
+
+	// CCI = (typical price - SMA) / (0.015 * mean deviation)
+	var value float64
+	if meanDeviation != 0 {
+		value = (typical - mean) / (0.015 * meanDeviation)
+	}
+	cci.AddOutput(value)
+}
+
+// AddValue is not the preferred method for CCI, but can be used for compatibility
+// with the Indicator interface. It will use the value as high, low and close.
+func (cci *CCI) AddValue(value float64) {
+	cci.AddOHLCValue(value, value, value)
+}
+
+// GetWindowSize returns the window size of the CCI
+func (cci *CCI) GetWindowSize() int {
+	return cci.windowSize
+}
+
+// Reset clears all values in the CCI
+func (cci *CCI) Reset() {
+	cci.BaseIndicator.Reset()
+	cci.typical.Reset()
+}
@@ -0,0 +1,69 @@
+package indicators
+
+import (
+	"github.com/revanthstrakz/gotalipp/talipp/ohlcv"
+)
+
+// ChaikinOsc represents the Chaikin Oscillator indicator, the difference
+// between a fast and a slow EMA of the Accumulation/Distribution Line
+type ChaikinOsc struct {
+	*BaseIndicator
+	accDist *AccDist
+	fastEMA *EMA
+	slowEMA *EMA
+}
+
+// NewChaikinOsc creates a new Chaikin Oscillator indicator
+// fastLength: the period for the fast EMA (default 3)
+// slowLength: the period for the slow EMA (default 10)
+func NewChaikinOsc(fastLength, slowLength int) *ChaikinOsc {
+	if fastLength <= 0 || slowLength <= 0 {
+		panic("All periods must be greater than 0")
+	}
+
+	if fastLength >= slowLength {
+		panic("Fast length must be less than slow length")
+	}
+
+	return &ChaikinOsc{
+		BaseIndicator: NewBaseIndicator("ChaikinOsc"),
+		accDist:       NewAccDist(),
+		fastEMA:       NewEMA(fastLength),
+		slowEMA:       NewEMA(slowLength),
+	}
+}
+
+// AddCandle adds a new candle to the Chaikin Oscillator calculation
+func (co *ChaikinOsc) AddCandle(candle *ohlcv.OHLCV) {
+	co.AddHLCVValue(candle.High, candle.Low, candle.Close, candle.Volume)
+}
+
+// AddHLCVValue adds a new high, low, close and volume to the Chaikin Oscillator calculation
+func (co *ChaikinOsc) AddHLCVValue(high, low, close, volume float64) {
+	co.accDist.AddHLCVValue(high, low, close, volume)
+	adValue, _ := co.accDist.GetLastValue()
+
+	co.fastEMA.AddValue(adValue)
+	co.slowEMA.AddValue(adValue)
+
+	if co.slowEMA.IsInitialized() {
+		fastValue, _ := co.fastEMA.GetLastValue()
+		slowValue, _ := co.slowEMA.GetLastValue()
+		co.AddOutput(fastValue - slowValue)
+	}
+}
+
+// AddValue is not the preferred method for ChaikinOsc, but can be used for compatibility
+// with the Indicator interface. It will use the value as high, low and close with a
+// volume of 1.
+func (co *ChaikinOsc) AddValue(value float64) {
+	co.AddHLCVValue(value, value, value, 1.0)
+}
+
+// Reset clears all values in the ChaikinOsc
+func (co *ChaikinOsc) Reset() {
+	co.BaseIndicator.Reset()
+	co.accDist.Reset()
+	co.fastEMA.Reset()
+	co.slowEMA.Reset()
+}
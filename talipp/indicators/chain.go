@@ -0,0 +1,73 @@
+package indicators
+
+// Chain is an indicator that feeds every new output of an indicator into the
+// next one, e.g. the ROC of a triple smoothed EMA. The output of the chain is
+// the output of its last indicator.
+//
+// An indicator only feeds the next one when an input has produced a new
+// output, so indicators reporting swings such as ZigZag or PivotHighLow pass
+// on a value per swing rather than per input. MACD and Stoch add several
+// interleaved outputs per input and cannot be part of a chain; chain their
+// moving averages instead.
+type Chain struct {
+	*BaseIndicator
+	stages []ValueIndicator
+}
+
+// NewChain creates a new chain of indicators, the first one receiving the input values
+func NewChain(stages ...ValueIndicator) *Chain {
+	if len(stages) == 0 {
+		panic("Chain must contain at least one indicator")
+	}
+	for _, stage := range stages {
+		switch stage.(type) {
+		case *MACD, *Stoch:
+			panic(stage.GetName() + " produces several outputs per value and cannot be chained")
+		}
+	}
+
+	return &Chain{
+		BaseIndicator: NewBaseIndicator("Chain"),
+		stages:        stages,
+	}
+}
+
+// AddValue adds a new value to the first indicator of the chain
+func (chain *Chain) AddValue(value float64) {
+	if feedChain(value, chain.stages...) {
+		lastValue, _ := chain.stages[len(chain.stages)-1].GetLastValue()
+		chain.AddOutput(lastValue)
+	}
+}
+
+// Reset clears all values in the chain and its indicators
+func (chain *Chain) Reset() {
+	chain.BaseIndicator.Reset()
+	for _, stage := range chain.stages {
+		stage.Reset()
+	}
+}
+
+// feedChain feeds a value through a chain of indicators, each one receiving
+// the new output of the previous one. It returns whether the last indicator
+// of the chain has produced a new value.
+func feedChain(value float64, chain ...ValueIndicator) bool {
+	for _, stage := range chain {
+		count := outputLen(stage)
+		stage.AddValue(value)
+		if outputLen(stage) == count {
+			return false
+		}
+		value, _ = stage.GetLastValue()
+	}
+	return true
+}
+
+// outputLen returns the number of outputs of an indicator without copying them
+// when it is built on BaseIndicator
+func outputLen(indicator ValueIndicator) int {
+	if base, ok := indicator.(interface{ outputLen() int }); ok {
+		return base.outputLen()
+	}
+	return len(indicator.GetOutput())
+}
@@ -0,0 +1,80 @@
+package indicators
+
+// Chandelier represents the Chandelier Exit indicator.
+//
+// The lower line is the long exit, the highest high of the window minus a
+// multiple of the ATR. The upper line is the short exit, the lowest low of the
+// window plus a multiple of the ATR. The middle line is their average.
+type Chandelier struct {
+	*BaseIndicator
+	channel
+	windowSize int
+	multiplier float64
+	atr        *ATR
+	extremes   *rollingExtremes
+}
+
+// NewChandelier creates a new Chandelier Exit indicator
+// windowSize: the period for the highest high, lowest low and ATR (default 22)
+// multiplier: the ATR multiple (default 3.0)
+func NewChandelier(windowSize int, multiplier float64) *Chandelier {
+	if windowSize <= 0 {
+		panic("Window size must be greater than 0")
+	}
+
+	return &Chandelier{
+		BaseIndicator: NewBaseIndicator("Chandelier"),
+		channel:       newChannel(),
+		windowSize:    windowSize,
+		multiplier:    multiplier,
+		atr:           NewATR(windowSize),
+		extremes:      newRollingExtremes(windowSize),
+	}
+}
+
+// AddOHLCValue adds a new OHLC candle data to the Chandelier Exit calculation
+func (ce *Chandelier) AddOHLCValue(high, low, close float64) {
+	ce.atr.AddOHLCValue(high, low, close)
+	ce.extremes.Add(high, low)
+
+	if ce.atr.IsInitialized() {
+		highestHigh := ce.extremes.Max()
+		lowestLow := ce.extremes.Min()
+
+		atrValue, _ := ce.atr.GetLastValue()
+		longExit := highestHigh - ce.multiplier*atrValue
+		shortExit := lowestLow + ce.multiplier*atrValue
+		ce.addBands(shortExit, (shortExit+longExit)/2.0, longExit)
+		ce.AddOutput(longExit)
+	}
+}
+
+// AddValue is not the preferred method for Chandelier, but can be used for compatibility
+// with the Indicator interface. It will use the value as high, low and close.
+// The base indicator output is the long exit.
+func (ce *Chandelier) AddValue(value float64) {
+	ce.AddOHLCValue(value, value, value)
+}
+
+// GetWindowSize returns the window size of the Chandelier Exit
+func (ce *Chandelier) GetWindowSize() int {
+	return ce.windowSize
+}
+
+// GetLongExit returns the long exit values (the lower line)
+func (ce *Chandelier) GetLongExit() []float64 {
+	return ce.GetLowerBand()
+}
+
+// GetShortExit returns the short exit values (the upper line)
+func (ce *Chandelier) GetShortExit() []float64 {
+	return ce.GetUpperBand()
+}
+
+// Reset clears all values in the Chandelier Exit
+func (ce *Chandelier) Reset() {
+	ce.BaseIndicator.Reset()
+	ce.resetBands()
+	ce.atr.Reset()
+	ce.extremes.Reset()
+}
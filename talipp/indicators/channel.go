@@ -0,0 +1,69 @@
+package indicators
+
+// ChannelOutput represents the upper, middle and lower lines of a band or
+// channel indicator. It is the same type as BBandsOutput.
+type ChannelOutput = BBandsOutput
+
+// channel stores the upper, middle and lower lines of band and channel
+// indicators and provides their getters
+type channel struct {
+	upperBands  []float64
+	middleBands []float64
+	lowerBands  []float64
+}
+
+// newChannel creates a new empty channel
+func newChannel() channel {
+	return channel{
+		upperBands:  make([]float64, 0),
+		middleBands: make([]float64, 0),
+		lowerBands:  make([]float64, 0),
+	}
+}
+
+// addBands stores the lines of a new bar
+func (ch *channel) addBands(upper, middle, lower float64) {
+	ch.upperBands = append(ch.upperBands, upper)
+	ch.middleBands = append(ch.middleBands, middle)
+	ch.lowerBands = append(ch.lowerBands, lower)
+}
+
+// resetBands clears all lines
+func (ch *channel) resetBands() {
+	*ch = newChannel()
+}
+
+// GetChannelOutput returns the complete channel output (Upper, Middle, Lower)
+func (ch *channel) GetChannelOutput() []ChannelOutput {
+	results := make([]ChannelOutput, len(ch.middleBands))
+	for i := range ch.middleBands {
+		results[i] = ChannelOutput{
+			Upper:  ch.upperBands[i],
+			Middle: ch.middleBands[i],
+			Lower:  ch.lowerBands[i],
+		}
+	}
+
+	return results
+}
+
+// GetUpperBand returns just the upper band values
+func (ch *channel) GetUpperBand() []float64 {
+	result := make([]float64, len(ch.upperBands))
+	copy(result, ch.upperBands)
+	return result
+}
+
+// GetMiddleBand returns just the middle band values
+func (ch *channel) GetMiddleBand() []float64 {
+	result := make([]float64, len(ch.middleBands))
+	copy(result, ch.middleBands)
+	return result
+}
+
+// GetLowerBand returns just the lower band values
+func (ch *channel) GetLowerBand() []float64 {
+	result := make([]float64, len(ch.lowerBands))
+	copy(result, ch.lowerBands)
+	return result
+}
@@ -0,0 +1,74 @@
+package indicators
+
+import (
+	"math"
+)
+
+// Choppiness represents the Choppiness Index indicator. Values close to 100
+// indicate a sideways market and values close to 0 a trending market.
+type Choppiness struct {
+	*BaseIndicator
+	windowSize    int
+	prevClose     float64
+	firstValueSet bool
+	trueRange     *rollingStats
+	extremes      *rollingExtremes
+}
+
+// NewChoppiness creates a new Choppiness Index indicator with specified window size (default 14)
+func NewChoppiness(windowSize int) *Choppiness {
+	if windowSize <= 1 {
+		panic("Window size must be greater than 1")
+	}
+
+	return &Choppiness{
+		BaseIndicator: NewBaseIndicator("Choppiness"),
+		windowSize:    windowSize,
+		trueRange:     newRollingStats(windowSize),
+		extremes:      newRollingExtremes(windowSize),
+	}
+}
+
+// AddOHLCValue adds a new OHLC candle data to the Choppiness Index calculation
+func (chop *Choppiness) AddOHLCValue(high, low, close float64) {
+	// The first true range is the range of the candle, as in ATR
+	trueRange := high - low
+	if chop.firstValueSet {
+		trueRange = calculateTrueRange(high, low, chop.prevClose)
+	}
+	chop.prevClose = close
+	chop.firstValueSet = true
+
+	chop.trueRange.Add(trueRange)
+	chop.extremes.Add(high, low)
+	if !chop.trueRange.Full() {
+		return
+	}
+
+	// CHOP = 100 * log10(sum of true ranges / (highest high - lowest low)) / log10(windowSize)
+	var value float64
+	if rangeSize := chop.extremes.Max() - chop.extremes.Min(); rangeSize > 0 {
+		value = 100.0 * math.Log10(chop.trueRange.Sum()/rangeSize) / math.Log10(float64(chop.windowSize))
+	}
+	chop.AddOutput(value)
+}
+
+// AddValue is not the preferred method for Choppiness, but can be used for compatibility
+// with the Indicator interface. It will use the value as high, low and close.
+func (chop *Choppiness) AddValue(value float64) {
+	chop.AddOHLCValue(value, value, value)
+}
+
+// GetWindowSize returns the window size of the Choppiness Index
+func (chop *Choppiness) GetWindowSize() int {
+	return chop.windowSize
+}
+
+// Reset clears all values in the Choppiness Index
+func (chop *Choppiness) Reset() {
+	chop.BaseIndicator.Reset()
+	chop.prevClose = 0
+	chop.firstValueSet = false
+	chop.trueRange.Reset()
+	chop.extremes.Reset()
+}
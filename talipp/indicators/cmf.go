@@ -0,0 +1,66 @@
+package indicators
+
+import (
+	"github.com/revanthstrakz/gotalipp/talipp/ohlcv"
+)
+
+// CMF represents the Chaikin Money Flow indicator
+type CMF struct {
+	*BaseIndicator
+	windowSize int
+	moneyFlow  *rollingStats
+	volume     *rollingStats
+}
+
+// NewCMF creates a new Chaikin Money Flow indicator with specified window size (default 20)
+func NewCMF(windowSize int) *CMF {
+	if windowSize <= 0 {
+		panic("Window size must be greater than 0")
+	}
+
+	return &CMF{
+		BaseIndicator: NewBaseIndicator("CMF"),
+		windowSize:    windowSize,
+		moneyFlow:     newRollingStats(windowSize),
+		volume:        newRollingStats(windowSize),
+	}
+}
+
+// AddCandle adds a new candle to the CMF calculation
+func (cmf *CMF) AddCandle(candle *ohlcv.OHLCV) {
+	cmf.AddHLCVValue(candle.High, candle.Low, candle.Close, candle.Volume)
+}
+
+// AddHLCVValue adds a new high, low, close and volume to the CMF calculation
+func (cmf *CMF) AddHLCVValue(high, low, close, volume float64) {
+	cmf.moneyFlow.Add(moneyFlowMultiplier(high, low, close) * volume)
+	cmf.volume.Add(volume)
+
+	if cmf.volume.Full() {
+		// CMF = sum(money flow volume) / sum(volume)
+		var value float64
+		if totalVolume := cmf.volume.Sum(); totalVolume != 0 {
+			value = cmf.moneyFlow.Sum() / totalVolume
+		}
+		cmf.AddOutput(value)
+	}
+}
+
+// AddValue is not the preferred method for CMF, but can be used for compatibility
+// with the Indicator interface. It will use the value as high, low and close with a
+// volume of 1.
+func (cmf *CMF) AddValue(value float64) {
+	cmf.AddHLCVValue(value, value, value, 1.0)
+}
+
+// GetWindowSize returns the window size of the CMF
+func (cmf *CMF) GetWindowSize() int {
+	return cmf.windowSize
+}
+
+// Reset clears all values in the CMF
+func (cmf *CMF) Reset() {
+	cmf.BaseIndicator.Reset()
+	cmf.moneyFlow.Reset()
+	cmf.volume.Reset()
+}
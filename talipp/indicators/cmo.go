@@ -0,0 +1,69 @@
+package indicators
+
+// CMO represents the Chande Momentum Oscillator indicator
+type CMO struct {
+	*BaseIndicator
+	windowSize    int
+	prevValue     float64
+	firstValueSet bool
+	gains         *rollingStats
+	losses        *rollingStats
+}
+
+// NewCMO creates a new Chande Momentum Oscillator indicator with specified window size (default 14)
+func NewCMO(windowSize int) *CMO {
+	if windowSize <= 0 {
+		panic("Window size must be greater than 0")
+	}
+
+	return &CMO{
+		BaseIndicator: NewBaseIndicator("CMO"),
+		windowSize:    windowSize,
+		gains:         newRollingStats(windowSize),
+		losses:        newRollingStats(windowSize),
+	}
+}
+
+// AddValue adds a new value to the CMO calculation
+func (cmo *CMO) AddValue(value float64) {
+	if !cmo.firstValueSet {
+		cmo.prevValue = value
+		cmo.firstValueSet = true
+		return
+	}
+
+	change := value - cmo.prevValue
+	cmo.prevValue = value
+	if change > 0 {
+		cmo.gains.Add(change)
+		cmo.losses.Add(0)
+	} else {
+		cmo.gains.Add(0)
+		cmo.losses.Add(-change)
+	}
+
+	if cmo.gains.Full() {
+		// CMO = 100 * (sum of gains - sum of losses) / (sum of gains + sum of losses)
+		gainSum := cmo.gains.Sum()
+		lossSum := cmo.losses.Sum()
+		var cmoValue float64
+		if gainSum+lossSum != 0 {
+			cmoValue = 100.0 * (gainSum - lossSum) / (gainSum + lossSum)
+		}
+		cmo.AddOutput(cmoValue)
+	}
+}
+
+// GetWindowSize returns the window size of the CMO
+func (cmo *CMO) GetWindowSize() int {
+	return cmo.windowSize
+}
+
+// Reset clears all values in the CMO
+func (cmo *CMO) Reset() {
+	cmo.BaseIndicator.Reset()
+	cmo.prevValue = 0
+	cmo.firstValueSet = false
+	cmo.gains.Reset()
+	cmo.losses.Reset()
+}
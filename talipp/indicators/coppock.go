@@ -0,0 +1,53 @@
+package indicators
+
+// Coppock represents the Coppock Curve indicator, a WMA of the sum of a long
+// and a short rate of change
+type Coppock struct {
+	*BaseIndicator
+	longROC  *ROC
+	shortROC *ROC
+	wma      *WMA
+}
+
+// NewCoppock creates a new Coppock Curve indicator
+// longROCLength: the period for the long rate of change (default 14)
+// shortROCLength: the period for the short rate of change (default 11)
+// wmaLength: the period for the WMA (default 10)
+func NewCoppock(longROCLength, shortROCLength, wmaLength int) *Coppock {
+	if longROCLength <= 0 || shortROCLength <= 0 || wmaLength <= 0 {
+		panic("All periods must be greater than 0")
+	}
+
+	return &Coppock{
+		BaseIndicator: NewBaseIndicator("Coppock"),
+		longROC:       NewROC(longROCLength),
+		shortROC:      NewROC(shortROCLength),
+		wma:           NewWMA(wmaLength),
+	}
+}
+
+// AddValue adds a new value to the Coppock Curve calculation
+func (cc *Coppock) AddValue(value float64) {
+	cc.longROC.AddValue(value)
+	cc.shortROC.AddValue(value)
+	if !cc.longROC.IsInitialized() || !cc.shortROC.IsInitialized() {
+		return
+	}
+
+	longValue, _ := cc.longROC.GetLastValue()
+	shortValue, _ := cc.shortROC.GetLastValue()
+	cc.wma.AddValue(longValue + shortValue)
+
+	if cc.wma.IsInitialized() {
+		wmaValue, _ := cc.wma.GetLastValue()
+		cc.AddOutput(wmaValue)
+	}
+}
+
+// Reset clears all values in the Coppock Curve
+func (cc *Coppock) Reset() {
+	cc.BaseIndicator.Reset()
+	cc.longROC.Reset()
+	cc.shortROC.Reset()
+	cc.wma.Reset()
+}
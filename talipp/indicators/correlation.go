@@ -0,0 +1,38 @@
+package indicators
+
+// Correlation represents the rolling Pearson correlation coefficient of two
+// series, ranging from -1 to 1
+type Correlation struct {
+	*BaseIndicator
+	windowSize int
+	covariance *rollingCovariance
+}
+
+// NewCorrelation creates a new rolling correlation indicator with specified window size (default 20)
+func NewCorrelation(windowSize int) *Correlation {
+	return &Correlation{
+		BaseIndicator: NewBaseIndicator("Correlation"),
+		windowSize:    windowSize,
+		covariance:    newRollingCovariance(windowSize),
+	}
+}
+
+// AddPairValue adds the next value of both series to the correlation calculation
+func (corr *Correlation) AddPairValue(first, second float64) {
+	corr.covariance.Add(first, second)
+
+	if corr.covariance.Full() {
+		corr.AddOutput(corr.covariance.Correlation())
+	}
+}
+
+// GetWindowSize returns the window size of the Correlation
+func (corr *Correlation) GetWindowSize() int {
+	return corr.windowSize
+}
+
+// Reset clears all values in the Correlation
+func (corr *Correlation) Reset() {
+	corr.BaseIndicator.Reset()
+	corr.covariance.Reset()
+}
@@ -0,0 +1,58 @@
+package indicators
+
+// Covariance represents the rolling covariance of two series
+type Covariance struct {
+	*BaseIndicator
+	windowSize int
+	sample     bool
+	covariance *rollingCovariance
+}
+
+// CovarianceOption configures optional Covariance parameters
+type CovarianceOption func(*Covariance)
+
+// WithCovarianceSample makes the indicator use the sample (n-1) covariance
+// instead of the population one
+func WithCovarianceSample() CovarianceOption {
+	return func(cov *Covariance) {
+		cov.sample = true
+	}
+}
+
+// NewCovariance creates a new rolling covariance indicator with specified window size (default 20)
+func NewCovariance(windowSize int, opts ...CovarianceOption) *Covariance {
+	cov := &Covariance{
+		BaseIndicator: NewBaseIndicator("Covariance"),
+		windowSize:    windowSize,
+		covariance:    newRollingCovariance(windowSize),
+	}
+	for _, opt := range opts {
+		opt(cov)
+	}
+
+	return cov
+}
+
+// AddPairValue adds the next value of both series to the covariance calculation
+func (cov *Covariance) AddPairValue(first, second float64) {
+	cov.covariance.Add(first, second)
+
+	if cov.covariance.Full() {
+		if cov.sample {
+			cov.AddOutput(cov.covariance.SampleCovariance())
+		} else {
+			cov.AddOutput(cov.covariance.Covariance())
+		}
+	}
+}
+
+// GetWindowSize returns the window size of the Covariance
+func (cov *Covariance) GetWindowSize() int {
+	return cov.windowSize
+}
+
+// Reset clears all values in the Covariance
+func (cov *Covariance) Reset() {
+	cov.BaseIndicator.Reset()
+	cov.covariance.Reset()
+}
@@ -0,0 +1,47 @@
+package indicators
+
+// DEMA represents a Double Exponential Moving Average indicator
+type DEMA struct {
+	*BaseIndicator
+	windowSize int
+	ema1       *EMA
+	ema2       *EMA
+}
+
+// NewDEMA creates a new Double Exponential Moving Average indicator with specified window size
+func NewDEMA(windowSize int) *DEMA {
+	if windowSize <= 0 {
+		panic("Window size must be greater than 0")
+	}
+
+	return &DEMA{
+		BaseIndicator: NewBaseIndicator("DEMA"),
+		windowSize:    windowSize,
+		ema1:          NewEMA(windowSize),
+		ema2:          NewEMA(windowSize),
+	}
+}
+
+// AddValue adds a new value to the DEMA calculation
+func (dema *DEMA) AddValue(value float64) {
+	if !feedChain(value, dema.ema1, dema.ema2) {
+		return
+	}
+
+	// DEMA = 2 * EMA - EMA(EMA)
+	ema1Value, _ := dema.ema1.GetLastValue()
+	ema2Value, _ := dema.ema2.GetLastValue()
+	dema.AddOutput(2.0*ema1Value - ema2Value)
+}
+
+// GetWindowSize returns the window size of the DEMA
+func (dema *DEMA) GetWindowSize() int {
+	return dema.windowSize
+}
+
+// Reset clears all values in the DEMA
+func (dema *DEMA) Reset() {
+	dema.BaseIndicator.Reset()
+	dema.ema1.Reset()
+	dema.ema2.Reset()
+}
@@ -0,0 +1,203 @@
+package indicators
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/revanthstrakz/gotalipp/talipp/ohlcv"
+)
+
+// DivergenceKind identifies a kind of divergence between price and an indicator
+type DivergenceKind int
+
+const (
+	// RegularBullish is a lower low in price with a higher low in the indicator
+	RegularBullish DivergenceKind = iota
+	// RegularBearish is a higher high in price with a lower high in the indicator
+	RegularBearish
+	// HiddenBullish is a higher low in price with a lower low in the indicator
+	HiddenBullish
+	// HiddenBearish is a lower high in price with a higher high in the indicator
+	HiddenBearish
+)
+
+// String returns the name of the divergence kind
+func (k DivergenceKind) String() string {
+	switch k {
+	case RegularBullish:
+		return "RegularBullish"
+	case RegularBearish:
+		return "RegularBearish"
+	case HiddenBullish:
+		return "HiddenBullish"
+	case HiddenBearish:
+		return "HiddenBearish"
+	default:
+		return fmt.Sprintf("DivergenceKind(%d)", int(k))
+	}
+}
+
+// IsBullish returns whether the divergence points to rising prices
+func (k DivergenceKind) IsBullish() bool {
+	return k == RegularBullish || k == HiddenBullish
+}
+
+// Divergence represents a divergence between two price swings of the same
+// kind and the indicator values on the same bars
+type Divergence struct {
+	Kind DivergenceKind
+	// Start and End are the earlier and the later price swing. The divergence
+	// is known on End.ConfirmedIndex.
+	Start SwingPoint
+	End   SwingPoint
+	// StartValue and EndValue are the indicator values on the swing bars
+	StartValue float64
+	EndValue   float64
+}
+
+// DivergenceDetector finds regular and hidden divergences between a price
+// series and the output of any indicator, such as RSI, the MACD histogram or
+// Stoch %K.
+//
+// Price swings are N-bar pivot highs and lows (see PivotHighLow), so a
+// divergence is reported rightBars bars after its last swing. Each swing is
+// compared to the previous swing of the same kind if it is at most
+// maxLookback bars older. Bars are indexed from 0 in the order they are added;
+// to keep the indexes aligned with a stream while the indicator warms up, add
+// those bars with a NaN indicator value and their swings are skipped.
+type DivergenceDetector struct {
+	pivots       *PivotHighLow
+	rightBars    int
+	maxLookback  int
+	values       []float64
+	lastHigh     *divergenceSwing
+	lastLow      *divergenceSwing
+	divergences  []Divergence
+	onDivergence func(Divergence)
+}
+
+// divergenceSwing is a confirmed price swing with its indicator value
+type divergenceSwing struct {
+	point SwingPoint
+	value float64
+}
+
+// DivergenceOption configures optional DivergenceDetector parameters
+type DivergenceOption func(*DivergenceDetector)
+
+// WithDivergenceMaxLookback sets the largest distance in bars between the two
+// swings of a divergence (default 60)
+func WithDivergenceMaxLookback(bars int) DivergenceOption {
+	return func(dd *DivergenceDetector) {
+		dd.maxLookback = bars
+	}
+}
+
+// NewDivergenceDetector creates a new divergence detector
+// leftBars: the number of bars before a price swing (default 5)
+// rightBars: the number of bars after a price swing confirming it (default 5)
+func NewDivergenceDetector(leftBars, rightBars int, opts ...DivergenceOption) *DivergenceDetector {
+	dd := &DivergenceDetector{
+		pivots:      NewPivotHighLow(leftBars, rightBars),
+		rightBars:   rightBars,
+		maxLookback: 60,
+		values:      make([]float64, 0),
+		divergences: make([]Divergence, 0),
+	}
+	for _, opt := range opts {
+		opt(dd)
+	}
+	dd.pivots.OnSwing(dd.onSwing)
+
+	return dd
+}
+
+// OnDivergence sets a callback to be called with every new divergence
+func (dd *DivergenceDetector) OnDivergence(callback func(Divergence)) {
+	dd.onDivergence = callback
+}
+
+// AddCandleValue adds a candle and the indicator value of the same bar. Swing
+// highs use the candle highs and swing lows the candle lows.
+func (dd *DivergenceDetector) AddCandleValue(candle *ohlcv.OHLCV, value float64) {
+	dd.addIndicatorValue(value)
+	dd.pivots.AddCandle(candle)
+}
+
+// AddPairValue adds a price and the indicator value of the same bar
+func (dd *DivergenceDetector) AddPairValue(price, value float64) {
+	dd.addIndicatorValue(value)
+	dd.pivots.AddValue(price)
+}
+
+// addIndicatorValue keeps the indicator values of the bars a swing can be confirmed on
+func (dd *DivergenceDetector) addIndicatorValue(value float64) {
+	dd.values = append(dd.values, value)
+	if len(dd.values) > dd.rightBars+1 {
+		dd.values = dd.values[1:]
+	}
+}
+
+// onSwing compares a newly confirmed price swing with the previous one of the same kind
+func (dd *DivergenceDetector) onSwing(point SwingPoint) {
+	value := dd.values[len(dd.values)-1-(point.ConfirmedIndex-point.Index)]
+	if math.IsNaN(value) {
+		return
+	}
+
+	current := &divergenceSwing{point: point, value: value}
+	previous := dd.lastLow
+	if point.Kind == SwingHigh {
+		previous = dd.lastHigh
+		dd.lastHigh = current
+	} else {
+		dd.lastLow = current
+	}
+	if previous == nil || point.Index-previous.point.Index > dd.maxLookback {
+		return
+	}
+
+	priceDelta := point.Price - previous.point.Price
+	valueDelta := value - previous.value
+	var kind DivergenceKind
+	switch {
+	case point.Kind == SwingLow && priceDelta < 0 && valueDelta > 0:
+		kind = RegularBullish
+	case point.Kind == SwingLow && priceDelta > 0 && valueDelta < 0:
+		kind = HiddenBullish
+	case point.Kind == SwingHigh && priceDelta > 0 && valueDelta < 0:
+		kind = RegularBearish
+	case point.Kind == SwingHigh && priceDelta < 0 && valueDelta > 0:
+		kind = HiddenBearish
+	default:
+		return
+	}
+
+	divergence := Divergence{
+		Kind:       kind,
+		Start:      previous.point,
+		End:        point,
+		StartValue: previous.value,
+		EndValue:   value,
+	}
+	dd.divergences = append(dd.divergences, divergence)
+	if dd.onDivergence != nil {
+		dd.onDivergence(divergence)
+	}
+}
+
+// GetDivergences returns all divergences found so far
+func (dd *DivergenceDetector) GetDivergences() []Divergence {
+	result := make([]Divergence, len(dd.divergences))
+	copy(result, dd.divergences)
+	return result
+}
+
+// Reset clears all values in the DivergenceDetector
+func (dd *DivergenceDetector) Reset() {
+	dd.pivots.Reset()
+	dd.values = make([]float64, 0)
+	dd.lastHigh = nil
+	dd.lastLow = nil
+	dd.divergences = make([]Divergence, 0)
+}
@@ -0,0 +1,56 @@
+package indicators
+
+// Donchian represents the Donchian Channels indicator: the highest high and
+// lowest low of the window and their average
+type Donchian struct {
+	*BaseIndicator
+	channel
+	windowSize int
+	extremes   *rollingExtremes
+}
+
+// NewDonchian creates a new Donchian Channels indicator with specified window size (default 20)
+func NewDonchian(windowSize int) *Donchian {
+	if windowSize <= 0 {
+		panic("Window size must be greater than 0")
+	}
+
+	return &Donchian{
+		BaseIndicator: NewBaseIndicator("Donchian"),
+		channel:       newChannel(),
+		windowSize:    windowSize,
+		extremes:      newRollingExtremes(windowSize),
+	}
+}
+
+// AddOHLCValue adds a new OHLC candle data to the Donchian Channels calculation
+func (dc *Donchian) AddOHLCValue(high, low, close float64) {
+	dc.extremes.Add(high, low)
+
+	if dc.extremes.Full() {
+		highestHigh := dc.extremes.Max()
+		lowestLow := dc.extremes.Min()
+
+		middle := (highestHigh + lowestLow) / 2.0
+		dc.addBands(highestHigh, middle, lowestLow)
+		dc.AddOutput(middle)
+	}
+}
+
+// AddValue is not the preferred method for Donchian, but can be used for compatibility
+// with the Indicator interface. It will use the value as high, low and close.
+func (dc *Donchian) AddValue(value float64) {
+	dc.AddOHLCValue(value, value, value)
+}
+
+// GetWindowSize returns the window size of the Donchian Channels
+func (dc *Donchian) GetWindowSize() int {
+	return dc.windowSize
+}
+
+// Reset clears all values in the Donchian Channels
+func (dc *Donchian) Reset() {
+	dc.BaseIndicator.Reset()
+	dc.resetBands()
+	dc.extremes.Reset()
+}
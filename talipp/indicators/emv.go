@@ -0,0 +1,82 @@
+package indicators
+
+import (
+	"github.com/revanthstrakz/gotalipp/talipp/ohlcv"
+)
+
+// EMV represents the Ease of Movement indicator
+type EMV struct {
+	*BaseIndicator
+	windowSize    int
+	volumeDivisor float64
+	prevMidpoint  float64
+	firstValueSet bool
+	sma           *SMA
+}
+
+// NewEMV creates a new Ease of Movement indicator
+// windowSize: the period for the SMA of the single period values (default 14)
+// volumeDivisor: the scale applied to volume to keep values readable (default 10000)
+func NewEMV(windowSize int, volumeDivisor float64) *EMV {
+	if windowSize <= 0 {
+		panic("Window size must be greater than 0")
+	}
+	if volumeDivisor <= 0 {
+		panic("Volume divisor must be greater than 0")
+	}
+
+	return &EMV{
+		BaseIndicator: NewBaseIndicator("EMV"),
+		windowSize:    windowSize,
+		volumeDivisor: volumeDivisor,
+		sma:           NewSMA(windowSize),
+	}
+}
+
+// AddCandle adds a new candle to the EMV calculation
+func (emv *EMV) AddCandle(candle *ohlcv.OHLCV) {
+	emv.AddHLVValue(candle.High, candle.Low, candle.Volume)
+}
+
+// AddHLVValue adds a new high, low and volume to the EMV calculation
+func (emv *EMV) AddHLVValue(high, low, volume float64) {
+	midpoint := (high + low) / 2.0
+	if !emv.firstValueSet {
+		emv.prevMidpoint = midpoint
+		emv.firstValueSet = true
+		return
+	}
+
+	// EMV = distance moved / box ratio, box ratio = (volume / divisor) / (high - low)
+	var value float64
+	if volume != 0 {
+		value = emv.volumeDivisor * (midpoint - emv.prevMidpoint) * (high - low) / volume
+	}
+	emv.prevMidpoint = midpoint
+
+	emv.sma.AddValue(value)
+	if emv.sma.IsInitialized() {
+		smaValue, _ := emv.sma.GetLastValue()
+		emv.AddOutput(smaValue)
+	}
+}
+
+// AddValue is not the preferred method for EMV, but can be used for compatibility
+// with the Indicator interface. It will use the value as high and low with a volume
+// of 1.
+func (emv *EMV) AddValue(value float64) {
+	emv.AddHLVValue(value, value, 1.0)
+}
+
+// GetWindowSize returns the window size of the EMV
+func (emv *EMV) GetWindowSize() int {
+	return emv.windowSize
+}
+
+// Reset clears all values in the EMV
+func (emv *EMV) Reset() {
+	emv.BaseIndicator.Reset()
+	emv.prevMidpoint = 0
+	emv.firstValueSet = false
+	emv.sma.Reset()
+}
@@ -0,0 +1,70 @@
+package indicators
+
+// Envelopes represents the Moving Average Envelopes indicator: bands at a
+// fixed percentage above and below a moving average
+type Envelopes struct {
+	*BaseIndicator
+	channel
+	windowSize int
+	percent    float64
+	maType     MAType
+	ma         MovingAverage
+}
+
+// EnvelopesOption configures optional Envelopes parameters
+type EnvelopesOption func(*Envelopes)
+
+// WithEnvelopesMAType sets the moving average used for the middle line (default MATypeSMA)
+func WithEnvelopesMAType(maType MAType) EnvelopesOption {
+	return func(env *Envelopes) {
+		env.maType = maType
+	}
+}
+
+// NewEnvelopes creates a new Moving Average Envelopes indicator
+// windowSize: the period for the moving average (default 20)
+// percent: the distance of the bands from the moving average in percent (default 2.5)
+func NewEnvelopes(windowSize int, percent float64, opts ...EnvelopesOption) *Envelopes {
+	if windowSize <= 0 {
+		panic("Window size must be greater than 0")
+	}
+
+	env := &Envelopes{
+		BaseIndicator: NewBaseIndicator("Envelopes"),
+		channel:       newChannel(),
+		windowSize:    windowSize,
+		percent:       percent,
+		maType:        MATypeSMA,
+	}
+	for _, opt := range opts {
+		opt(env)
+	}
+	env.ma = NewMovingAverage(env.maType, windowSize)
+
+	return env
+}
+
+// AddValue adds a new value to the Envelopes calculation
+func (env *Envelopes) AddValue(value float64) {
+	env.ma.AddValue(value)
+
+	if env.ma.IsInitialized() {
+		middle, _ := env.ma.GetLastValue()
+		offset := middle * env.percent / 100.0
+
+		env.addBands(middle+offset, middle, middle-offset)
+		env.AddOutput(middle)
+	}
+}
+
+// GetWindowSize returns the window size of the Envelopes
+func (env *Envelopes) GetWindowSize() int {
+	return env.windowSize
+}
+
+// Reset clears all values in the Envelopes
+func (env *Envelopes) Reset() {
+	env.BaseIndicator.Reset()
+	env.resetBands()
+	env.ma.Reset()
+}
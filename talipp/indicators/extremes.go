@@ -0,0 +1,103 @@
+package indicators
+
+// extremeEntry is a value in a rolling extremes deque with its position in the input
+type extremeEntry struct {
+	index int
+	value float64
+}
+
+// rollingExtremes keeps the maximum of one series (usually the highs) and the
+// minimum of another (usually the lows) over a sliding window.
+//
+// Both are maintained with monotonic deques, which makes every update O(1)
+// amortised instead of scanning the whole window. On ties the most recent
+// value is reported as the extreme.
+type rollingExtremes struct {
+	windowSize int
+	count      int
+	maxDeque   []extremeEntry
+	minDeque   []extremeEntry
+}
+
+// newRollingExtremes creates a new rollingExtremes for the specified window size
+func newRollingExtremes(windowSize int) *rollingExtremes {
+	if windowSize <= 0 {
+		panic("Window size must be greater than 0")
+	}
+
+	return &rollingExtremes{
+		windowSize: windowSize,
+		maxDeque:   make([]extremeEntry, 0),
+		minDeque:   make([]extremeEntry, 0),
+	}
+}
+
+// Add pushes a new high and low into the window
+func (re *rollingExtremes) Add(high, low float64) {
+	index := re.count
+	re.count++
+
+	// Drop the values that can no longer be the maximum or minimum
+	for len(re.maxDeque) > 0 && re.maxDeque[len(re.maxDeque)-1].value <= high {
+		re.maxDeque = re.maxDeque[:len(re.maxDeque)-1]
+	}
+	re.maxDeque = append(re.maxDeque, extremeEntry{index: index, value: high})
+
+	for len(re.minDeque) > 0 && re.minDeque[len(re.minDeque)-1].value >= low {
+		re.minDeque = re.minDeque[:len(re.minDeque)-1]
+	}
+	re.minDeque = append(re.minDeque, extremeEntry{index: index, value: low})
+
+	// Drop the values that left the window
+	oldest := re.count - re.windowSize
+	if re.maxDeque[0].index < oldest {
+		re.maxDeque = re.maxDeque[1:]
+	}
+	if re.minDeque[0].index < oldest {
+		re.minDeque = re.minDeque[1:]
+	}
+}
+
+// Full returns whether the window holds windowSize values
+func (re *rollingExtremes) Full() bool {
+	return re.count >= re.windowSize
+}
+
+// Max returns the maximum of the highs in the window
+func (re *rollingExtremes) Max() float64 {
+	if len(re.maxDeque) == 0 {
+		return 0
+	}
+	return re.maxDeque[0].value
+}
+
+// Min returns the minimum of the lows in the window
+func (re *rollingExtremes) Min() float64 {
+	if len(re.minDeque) == 0 {
+		return 0
+	}
+	return re.minDeque[0].value
+}
+
+// MaxAge returns how many values ago the maximum was added, 0 being the latest value
+func (re *rollingExtremes) MaxAge() int {
+	if len(re.maxDeque) == 0 {
+		return 0
+	}
+	return re.count - 1 - re.maxDeque[0].index
+}
+
+// MinAge returns how many values ago the minimum was added, 0 being the latest value
+func (re *rollingExtremes) MinAge() int {
+	if len(re.minDeque) == 0 {
+		return 0
+	}
+	return re.count - 1 - re.minDeque[0].index
+}
+
+// Reset clears the window
+func (re *rollingExtremes) Reset() {
+	re.count = 0
+	re.maxDeque = make([]extremeEntry, 0)
+	re.minDeque = make([]extremeEntry, 0)
+}
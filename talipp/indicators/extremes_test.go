@@ -0,0 +1,93 @@
+package indicators
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollingExtremes(t *testing.T) {
+	const windowSize = 7
+	re := newRollingExtremes(windowSize)
+	rng := rand.New(rand.NewSource(42))
+
+	highs := make([]float64, 0)
+	lows := make([]float64, 0)
+	for i := 0; i < 1000; i++ {
+		// Rounded values produce plenty of ties
+		high := float64(rng.Intn(20))
+		low := float64(rng.Intn(20))
+		highs = append(highs, high)
+		lows = append(lows, low)
+		re.Add(high, low)
+
+		assert.Equal(t, i+1 >= windowSize, re.Full())
+
+		start := len(highs) - windowSize
+		if start < 0 {
+			start = 0
+		}
+		maxIdx, minIdx := start, start
+		for j := start; j < len(highs); j++ {
+			if highs[j] >= highs[maxIdx] {
+				maxIdx = j
+			}
+			if lows[j] <= lows[minIdx] {
+				minIdx = j
+			}
+		}
+		assert.Equal(t, highs[maxIdx], re.Max())
+		assert.Equal(t, lows[minIdx], re.Min())
+		assert.Equal(t, i-maxIdx, re.MaxAge())
+		assert.Equal(t, i-minIdx, re.MinAge())
+	}
+
+	re.Reset()
+	assert.False(t, re.Full())
+	re.Add(3, 1)
+	assert.Equal(t, 3.0, re.Max())
+	assert.Equal(t, 1.0, re.Min())
+}
+
+// benchmarkWindowSizes are the window sizes used by the rolling extremes benchmarks
+var benchmarkWindowSizes = []int{14, 200, 2000}
+
+// benchmarkPrices returns a deterministic random walk of n prices
+func benchmarkPrices(n int) []float64 {
+	rng := rand.New(rand.NewSource(1))
+	prices := make([]float64, n)
+	price := 100.0
+	for i := range prices {
+		price += rng.Float64() - 0.5
+		prices[i] = price
+	}
+	return prices
+}
+
+func BenchmarkRollingExtremes(b *testing.B) {
+	prices := benchmarkPrices(4096)
+	for _, windowSize := range benchmarkWindowSizes {
+		b.Run(fmt.Sprintf("window=%d", windowSize), func(b *testing.B) {
+			re := newRollingExtremes(windowSize)
+			for i := 0; i < b.N; i++ {
+				price := prices[i%len(prices)]
+				re.Add(price+0.5, price-0.5)
+			}
+		})
+	}
+}
+
+func BenchmarkStoch(b *testing.B) {
+	prices := benchmarkPrices(4096)
+	for _, windowSize := range benchmarkWindowSizes {
+		b.Run(fmt.Sprintf("window=%d", windowSize), func(b *testing.B) {
+			stoch := NewStoch(windowSize, 3, 3)
+			for i := 0; i < b.N; i++ {
+				price := prices[i%len(prices)]
+				stoch.AddHLCValue(price+0.5, price-0.5, price)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package indicators
+
+import (
+	"github.com/revanthstrakz/gotalipp/talipp/ohlcv"
+)
+
+// ForceIndex represents Elder's Force Index indicator, an EMA of the price
+// change multiplied by volume
+type ForceIndex struct {
+	*BaseIndicator
+	windowSize    int
+	prevClose     float64
+	firstValueSet bool
+	ema           *EMA
+}
+
+// NewForceIndex creates a new Force Index indicator with specified window size (default 13)
+func NewForceIndex(windowSize int) *ForceIndex {
+	if windowSize <= 0 {
+		panic("Window size must be greater than 0")
+	}
+
+	return &ForceIndex{
+		BaseIndicator: NewBaseIndicator("ForceIndex"),
+		windowSize:    windowSize,
+		ema:           NewEMA(windowSize),
+	}
+}
+
+// AddCandle adds a new candle to the Force Index calculation
+func (fi *ForceIndex) AddCandle(candle *ohlcv.OHLCV) {
+	fi.AddCloseVolumeValue(candle.Close, candle.Volume)
+}
+
+// AddCloseVolumeValue adds a new close and volume to the Force Index calculation
+func (fi *ForceIndex) AddCloseVolumeValue(close, volume float64) {
+	if !fi.firstValueSet {
+		fi.prevClose = close
+		fi.firstValueSet = true
+		return
+	}
+
+	// Force = (close - previous close) * volume
+	fi.ema.AddValue((close - fi.prevClose) * volume)
+	fi.prevClose = close
+
+	if fi.ema.IsInitialized() {
+		value, _ := fi.ema.GetLastValue()
+		fi.AddOutput(value)
+	}
+}
+
+// AddValue is not the preferred method for ForceIndex, but can be used for compatibility
+// with the Indicator interface. Every value gets a volume of 1.
+func (fi *ForceIndex) AddValue(value float64) {
+	fi.AddCloseVolumeValue(value, 1.0)
+}
+
+// GetWindowSize returns the window size of the Force Index
+func (fi *ForceIndex) GetWindowSize() int {
+	return fi.windowSize
+}
+
+// Reset clears all values in the Force Index
+func (fi *ForceIndex) Reset() {
+	fi.BaseIndicator.Reset()
+	fi.prevClose = 0
+	fi.firstValueSet = false
+	fi.ema.Reset()
+}
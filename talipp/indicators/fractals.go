@@ -0,0 +1,160 @@
+package indicators
+
+import (
+	"time"
+
+	"github.com/revanthstrakz/gotalipp/talipp/ohlcv"
+)
+
+// PivotHighLow represents N-bar pivot highs and lows: a pivot high is a bar
+// whose high is strictly greater than the highs of the leftBars bars before
+// it and of the rightBars bars after it, and a pivot low is the same for the
+// lows. Williams fractals are pivots with two bars on each side.
+//
+// A pivot is confirmed rightBars bars after it happened. Until then it is
+// tentative and disappears if a later bar goes beyond it. The base indicator
+// output holds the price of every confirmed pivot.
+type PivotHighLow struct {
+	*BaseIndicator
+	swings
+	leftBars   int
+	rightBars  int
+	highs      []float64
+	lows       []float64
+	timestamps []time.Time
+}
+
+// NewPivotHighLow creates a new N-bar pivot high/low indicator
+// leftBars: the number of bars before the pivot (default 5)
+// rightBars: the number of bars after the pivot confirming it (default 5)
+func NewPivotHighLow(leftBars, rightBars int) *PivotHighLow {
+	return newPivotHighLow("PivotHighLow", leftBars, rightBars)
+}
+
+// NewFractals creates a new Williams fractals indicator, pivots with two bars on each side
+func NewFractals() *PivotHighLow {
+	return newPivotHighLow("Fractals", 2, 2)
+}
+
+// newPivotHighLow creates a PivotHighLow with the given name
+func newPivotHighLow(name string, leftBars, rightBars int) *PivotHighLow {
+	if leftBars < 0 || rightBars < 0 {
+		panic("Number of bars must not be negative")
+	}
+
+	return &PivotHighLow{
+		BaseIndicator: NewBaseIndicator(name),
+		swings:        newSwings(),
+		leftBars:      leftBars,
+		rightBars:     rightBars,
+		highs:         make([]float64, 0),
+		lows:          make([]float64, 0),
+		timestamps:    make([]time.Time, 0),
+	}
+}
+
+// AddCandle adds a new candle to the pivot calculation
+func (phl *PivotHighLow) AddCandle(candle *ohlcv.OHLCV) {
+	phl.addBar(candle.High, candle.Low, candle.Timestamp)
+}
+
+// AddOHLCValue adds a new OHLC candle data to the pivot calculation
+func (phl *PivotHighLow) AddOHLCValue(high, low, close float64) {
+	phl.addBar(high, low, time.Time{})
+}
+
+// AddValue is not the preferred method for PivotHighLow, but can be used for compatibility
+// with the Indicator interface. It will use the value as high, low and close.
+func (phl *PivotHighLow) AddValue(value float64) {
+	phl.AddOHLCValue(value, value, value)
+}
+
+// addBar adds a new bar to the pivot calculation
+func (phl *PivotHighLow) addBar(high, low float64, timestamp time.Time) {
+	phl.bars++
+	phl.highs = append(phl.highs, high)
+	phl.lows = append(phl.lows, low)
+	phl.timestamps = append(phl.timestamps, timestamp)
+
+	// Keep only the bars around the pivot being confirmed
+	if size := phl.leftBars + phl.rightBars + 1; len(phl.highs) > size {
+		phl.highs = phl.highs[1:]
+		phl.lows = phl.lows[1:]
+		phl.timestamps = phl.timestamps[1:]
+	}
+
+	if len(phl.highs) < phl.leftBars+phl.rightBars+1 {
+		return
+	}
+
+	// The candidate has exactly rightBars bars after it
+	if point, ok := phl.pivotAt(SwingHigh, phl.leftBars); ok {
+		phl.confirm(point)
+		phl.AddOutput(point.Price)
+	}
+	if point, ok := phl.pivotAt(SwingLow, phl.leftBars); ok {
+		phl.confirm(point)
+		phl.AddOutput(point.Price)
+	}
+}
+
+// pivotAt returns the pivot of the given kind at position i of the buffer, if
+// its value is beyond the leftBars bars before it and all the bars after it
+func (phl *PivotHighLow) pivotAt(kind SwingKind, i int) (SwingPoint, bool) {
+	if i < phl.leftBars {
+		return SwingPoint{}, false
+	}
+
+	values := phl.highs
+	if kind == SwingLow {
+		values = phl.lows
+	}
+	price := values[i]
+	for j := i - phl.leftBars; j < len(values); j++ {
+		if j != i && float64(kind)*(values[j]-price) >= 0 {
+			return SwingPoint{}, false
+		}
+	}
+
+	return SwingPoint{
+		Kind:           kind,
+		Price:          price,
+		Index:          phl.bars - len(values) + i,
+		Timestamp:      phl.timestamps[i],
+		ConfirmedIndex: -1,
+	}, true
+}
+
+// GetTentative returns the pivots among the last rightBars bars that are
+// beyond the bars around them so far but do not have rightBars bars after
+// them yet. They may still disappear.
+func (phl *PivotHighLow) GetTentative() []SwingPoint {
+	result := make([]SwingPoint, 0)
+	start := len(phl.highs) - phl.rightBars
+	if start < 0 {
+		start = 0
+	}
+	for i := start; i < len(phl.highs); i++ {
+		if point, ok := phl.pivotAt(SwingHigh, i); ok {
+			result = append(result, point)
+		}
+		if point, ok := phl.pivotAt(SwingLow, i); ok {
+			result = append(result, point)
+		}
+	}
+	return result
+}
+
+// GetWindowSize returns the number of bars around a pivot, leftBars + rightBars + 1
+func (phl *PivotHighLow) GetWindowSize() int {
+	return phl.leftBars + phl.rightBars + 1
+}
+
+// Reset clears all values in the PivotHighLow
+func (phl *PivotHighLow) Reset() {
+	phl.BaseIndicator.Reset()
+	phl.resetSwings()
+	phl.highs = make([]float64, 0)
+	phl.lows = make([]float64, 0)
+	phl.timestamps = make([]time.Time, 0)
+}
@@ -0,0 +1,66 @@
+package indicators
+
+import (
+	"math"
+)
+
+// HMA represents a Hull Moving Average indicator
+type HMA struct {
+	*BaseIndicator
+	windowSize int
+	halfWMA    *WMA
+	fullWMA    *WMA
+	hullWMA    *WMA
+}
+
+// NewHMA creates a new Hull Moving Average indicator with specified window size
+func NewHMA(windowSize int) *HMA {
+	if windowSize <= 0 {
+		panic("Window size must be greater than 0")
+	}
+
+	halfSize := windowSize / 2
+	if halfSize < 1 {
+		halfSize = 1
+	}
+
+	return &HMA{
+		BaseIndicator: NewBaseIndicator("HMA"),
+		windowSize:    windowSize,
+		halfWMA:       NewWMA(halfSize),
+		fullWMA:       NewWMA(windowSize),
+		hullWMA:       NewWMA(int(math.Sqrt(float64(windowSize)))),
+	}
+}
+
+// AddValue adds a new value to the HMA calculation
+func (hma *HMA) AddValue(value float64) {
+	hma.halfWMA.AddValue(value)
+	hma.fullWMA.AddValue(value)
+	if !hma.fullWMA.IsInitialized() {
+		return
+	}
+
+	// HMA = WMA(2 * WMA(n/2) - WMA(n), sqrt(n))
+	halfValue, _ := hma.halfWMA.GetLastValue()
+	fullValue, _ := hma.fullWMA.GetLastValue()
+	hma.hullWMA.AddValue(2.0*halfValue - fullValue)
+
+	if hma.hullWMA.IsInitialized() {
+		hullValue, _ := hma.hullWMA.GetLastValue()
+		hma.AddOutput(hullValue)
+	}
+}
+
+// GetWindowSize returns the window size of the HMA
+func (hma *HMA) GetWindowSize() int {
+	return hma.windowSize
+}
+
+// Reset clears all values in the HMA
+func (hma *HMA) Reset() {
+	hma.BaseIndicator.Reset()
+	hma.halfWMA.Reset()
+	hma.fullWMA.Reset()
+	hma.hullWMA.Reset()
+}
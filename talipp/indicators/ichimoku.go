@@ -0,0 +1,195 @@
+package indicators
+
+// DisplacedValue is an indicator value together with the bar index it belongs
+// to. Bars are counted from 0 in the order they were added to the indicator,
+// so when every candle of an ohlcv.Stream is added the index matches
+// Stream.Data. Forward displaced values may point past the last bar and
+// backward displaced values may be negative.
+type DisplacedValue struct {
+	Index int
+	Value float64
+}
+
+// Ichimoku represents the Ichimoku Kinko Hyo indicator
+type Ichimoku struct {
+	*BaseIndicator
+	tenkanPeriod       int
+	kijunPeriod        int
+	senkouBPeriod      int
+	displacement       int
+	chikouDisplacement int
+	bars               int
+	tenkanExtremes     *rollingExtremes
+	kijunExtremes      *rollingExtremes
+	senkouBExtremes    *rollingExtremes
+	tenkan             []DisplacedValue
+	kijun              []DisplacedValue
+	senkouA            []DisplacedValue
+	senkouB            []DisplacedValue
+	chikou             []DisplacedValue
+}
+
+// IchimokuOutput represents the Ichimoku lines computed on one bar, each one
+// carrying the bar index it has to be plotted at
+type IchimokuOutput struct {
+	Tenkan  DisplacedValue
+	Kijun   DisplacedValue
+	SenkouA DisplacedValue
+	SenkouB DisplacedValue
+	Chikou  DisplacedValue
+}
+
+// NewIchimoku creates a new Ichimoku Kinko Hyo indicator
+// tenkanPeriod: the period for the conversion line (default 9)
+// kijunPeriod: the period for the base line (default 26)
+// senkouBPeriod: the period for leading span B (default 52)
+// displacement: how many bars the leading spans are shifted forward (default 26)
+// chikouDisplacement: how many bars the lagging span is shifted backward (default 26)
+func NewIchimoku(tenkanPeriod, kijunPeriod, senkouBPeriod, displacement, chikouDisplacement int) *Ichimoku {
+	if tenkanPeriod <= 0 || kijunPeriod <= 0 || senkouBPeriod <= 0 {
+		panic("All periods must be greater than 0")
+	}
+	if displacement < 0 || chikouDisplacement < 0 {
+		panic("Displacements must not be negative")
+	}
+
+	return &Ichimoku{
+		BaseIndicator:      NewBaseIndicator("Ichimoku"),
+		tenkanPeriod:       tenkanPeriod,
+		kijunPeriod:        kijunPeriod,
+		senkouBPeriod:      senkouBPeriod,
+		displacement:       displacement,
+		chikouDisplacement: chikouDisplacement,
+		tenkanExtremes:     newRollingExtremes(tenkanPeriod),
+		kijunExtremes:      newRollingExtremes(kijunPeriod),
+		senkouBExtremes:    newRollingExtremes(senkouBPeriod),
+		tenkan:             make([]DisplacedValue, 0),
+		kijun:              make([]DisplacedValue, 0),
+		senkouA:            make([]DisplacedValue, 0),
+		senkouB:            make([]DisplacedValue, 0),
+		chikou:             make([]DisplacedValue, 0),
+	}
+}
+
+// AddOHLCValue adds a new OHLC candle data to the Ichimoku calculation
+func (ich *Ichimoku) AddOHLCValue(high, low, close float64) {
+	index := ich.bars
+	ich.bars++
+
+	ich.tenkanExtremes.Add(high, low)
+	ich.kijunExtremes.Add(high, low)
+	ich.senkouBExtremes.Add(high, low)
+
+	// The lagging span is the close plotted in the past
+	ich.chikou = append(ich.chikou, DisplacedValue{Index: index - ich.chikouDisplacement, Value: close})
+
+	tenkan, tenkanOK := midpoint(ich.tenkanExtremes)
+	if tenkanOK {
+		ich.tenkan = append(ich.tenkan, DisplacedValue{Index: index, Value: tenkan})
+		ich.AddOutput(tenkan)
+	}
+
+	kijun, kijunOK := midpoint(ich.kijunExtremes)
+	if kijunOK {
+		ich.kijun = append(ich.kijun, DisplacedValue{Index: index, Value: kijun})
+	}
+
+	// Leading span A is the average of the conversion and base lines
+	if tenkanOK && kijunOK {
+		ich.senkouA = append(ich.senkouA, DisplacedValue{Index: index + ich.displacement, Value: (tenkan + kijun) / 2.0})
+	}
+
+	if senkouB, ok := midpoint(ich.senkouBExtremes); ok {
+		ich.senkouB = append(ich.senkouB, DisplacedValue{Index: index + ich.displacement, Value: senkouB})
+	}
+}
+
+// midpoint returns the average of the highest high and lowest low of a full window
+func midpoint(extremes *rollingExtremes) (float64, bool) {
+	if !extremes.Full() {
+		return 0, false
+	}
+
+	return (extremes.Max() + extremes.Min()) / 2.0, true
+}
+
+// AddValue is not the preferred method for Ichimoku, but can be used for compatibility
+// with the Indicator interface. It will use the value as high, low and close.
+// The base indicator output is the conversion line (Tenkan-sen).
+func (ich *Ichimoku) AddValue(value float64) {
+	ich.AddOHLCValue(value, value, value)
+}
+
+// GetWindowSize returns the period of the base line (Kijun-sen)
+func (ich *Ichimoku) GetWindowSize() int {
+	return ich.kijunPeriod
+}
+
+// Reset clears all values in the Ichimoku
+func (ich *Ichimoku) Reset() {
+	ich.BaseIndicator.Reset()
+	ich.bars = 0
+	ich.tenkanExtremes.Reset()
+	ich.kijunExtremes.Reset()
+	ich.senkouBExtremes.Reset()
+	ich.tenkan = make([]DisplacedValue, 0)
+	ich.kijun = make([]DisplacedValue, 0)
+	ich.senkouA = make([]DisplacedValue, 0)
+	ich.senkouB = make([]DisplacedValue, 0)
+	ich.chikou = make([]DisplacedValue, 0)
+}
+
+// GetIchimokuOutput returns all Ichimoku lines for every bar on which all of
+// them are available
+func (ich *Ichimoku) GetIchimokuOutput() []IchimokuOutput {
+	// Leading span B has the longest warm-up of the computed lines
+	resultLen := len(ich.senkouB)
+	if len(ich.senkouA) < resultLen {
+		resultLen = len(ich.senkouA)
+	}
+
+	results := make([]IchimokuOutput, resultLen)
+	for i := 0; i < resultLen; i++ {
+		results[i] = IchimokuOutput{
+			Tenkan:  ich.tenkan[len(ich.tenkan)-resultLen+i],
+			Kijun:   ich.kijun[len(ich.kijun)-resultLen+i],
+			SenkouA: ich.senkouA[len(ich.senkouA)-resultLen+i],
+			SenkouB: ich.senkouB[len(ich.senkouB)-resultLen+i],
+			Chikou:  ich.chikou[len(ich.chikou)-resultLen+i],
+		}
+	}
+
+	return results
+}
+
+// GetTenkan returns the conversion line (Tenkan-sen) values
+func (ich *Ichimoku) GetTenkan() []DisplacedValue {
+	return copyDisplacedValues(ich.tenkan)
+}
+
+// GetKijun returns the base line (Kijun-sen) values
+func (ich *Ichimoku) GetKijun() []DisplacedValue {
+	return copyDisplacedValues(ich.kijun)
+}
+
+// GetSenkouA returns the leading span A (Senkou Span A) values, displaced forward
+func (ich *Ichimoku) GetSenkouA() []DisplacedValue {
+	return copyDisplacedValues(ich.senkouA)
+}
+
+// GetSenkouB returns the leading span B (Senkou Span B) values, displaced forward
+func (ich *Ichimoku) GetSenkouB() []DisplacedValue {
+	return copyDisplacedValues(ich.senkouB)
+}
+
+// GetChikou returns the lagging span (Chikou Span) values, displaced backward
+func (ich *Ichimoku) GetChikou() []DisplacedValue {
+	return copyDisplacedValues(ich.chikou)
+}
+
+// copyDisplacedValues returns a copy of the given values
+func copyDisplacedValues(values []DisplacedValue) []DisplacedValue {
+	result := make([]DisplacedValue, len(values))
+	copy(result, values)
+	return result
+}
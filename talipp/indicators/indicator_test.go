@@ -1,8 +1,11 @@
 package indicators
 
 import (
+	"math"
 	"testing"
+	"time"
 
+	"github.com/revanthstrakz/gotalipp/talipp/ohlcv"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -187,3 +190,1678 @@ func TestStoch(t *testing.T) {
 		}
 	})
 }
+
+func TestRMA(t *testing.T) {
+	t.Run("Basic RMA calculation", func(t *testing.T) {
+		rma := NewRMA(3)
+
+		rma.AddValue(1.0)
+		rma.AddValue(2.0)
+		rma.AddValue(3.0) // First value is the SMA (2.0)
+
+		output := rma.GetOutput()
+		assert.Equal(t, 1, len(output))
+		assert.InDelta(t, 2.0, output[0], 0.0001)
+
+		// RMA = (2.0 * 2 + 5.0) / 3 = 3.0
+		rma.AddValue(5.0)
+		output = rma.GetOutput()
+		assert.Equal(t, 2, len(output))
+		assert.InDelta(t, 3.0, output[1], 0.0001)
+	})
+}
+
+func TestMovingAverageTypes(t *testing.T) {
+	t.Run("Factory creates the requested type", func(t *testing.T) {
+		assert.IsType(t, &SMA{}, NewMovingAverage(MATypeSMA, 3))
+		assert.IsType(t, &EMA{}, NewMovingAverage(MATypeEMA, 3))
+		assert.IsType(t, &RMA{}, NewMovingAverage(MATypeRMA, 3))
+		assert.Equal(t, "RMA", MATypeRMA.String())
+		assert.Panics(t, func() { NewMovingAverage(MAType(-1), 3) })
+	})
+
+	t.Run("MACD with SMA lines", func(t *testing.T) {
+		macd := NewMACD(2, 3, 2, WithMACDMAType(MATypeSMA), WithMACDSignalMAType(MATypeSMA))
+		for _, value := range []float64{1.0, 2.0, 4.0, 8.0} {
+			macd.AddValue(value)
+		}
+
+		// MACD line: SMA(2) - SMA(3) = 3 - 7/3 and 6 - 14/3
+		// Signal: SMA(2) of the MACD line
+		macdLine := macd.GetMACDLine()
+		assert.Equal(t, 2, len(macdLine))
+		assert.InDelta(t, 3.0-7.0/3.0, macdLine[0], 0.0001)
+		assert.InDelta(t, 6.0-14.0/3.0, macdLine[1], 0.0001)
+
+		signalLine := macd.GetSignalLine()
+		assert.Equal(t, 1, len(signalLine))
+		assert.InDelta(t, (3.0-7.0/3.0+6.0-14.0/3.0)/2.0, signalLine[0], 0.0001)
+
+		// The complete output pairs each signal value with the MACD value of the same bar
+		output := macd.GetMACDOutput()
+		assert.Equal(t, 1, len(output))
+		assert.InDelta(t, macdLine[1], output[0].MACD, 0.0001)
+		assert.InDelta(t, macdLine[1]-signalLine[0], output[0].Histogram, 0.0001)
+	})
+
+	t.Run("BBands with EMA middle band", func(t *testing.T) {
+		bb := NewBBands(3, 2.0, WithBBandsMAType(MATypeEMA))
+		for _, value := range []float64{1.0, 2.0, 3.0, 4.0} {
+			bb.AddValue(value)
+		}
+
+		// EMA(3): 2.0 then (4 - 2) * 0.5 + 2 = 3.0
+		output := bb.GetBBandsOutput()
+		assert.Equal(t, 2, len(output))
+		assert.InDelta(t, 3.0, output[1].Middle, 0.0001)
+	})
+
+	t.Run("ATR uses Wilder smoothing by default", func(t *testing.T) {
+		atr := NewATR(3)
+		sma := NewATR(3, WithATRMAType(MATypeSMA))
+		for _, candle := range [][3]float64{
+			{10.0, 8.0, 9.0},  // TR = 2.0
+			{11.0, 9.0, 10.0}, // TR = 2.0
+			{10.0, 7.0, 8.0},  // TR = 3.0
+			{14.0, 8.0, 13.0}, // TR = 6.0
+		} {
+			atr.AddOHLCValue(candle[0], candle[1], candle[2])
+			sma.AddOHLCValue(candle[0], candle[1], candle[2])
+		}
+
+		// Wilder: (7/3 * 2 + 6) / 3, SMA: (2 + 3 + 6) / 3
+		assert.InDelta(t, (7.0/3.0*2.0+6.0)/3.0, atr.GetOutput()[1], 0.0001)
+		assert.InDelta(t, 11.0/3.0, sma.GetOutput()[1], 0.0001)
+	})
+
+	t.Run("Stoch with EMA smoothing", func(t *testing.T) {
+		stoch := NewStoch(1, 1, 3, WithStochMAType(MATypeEMA))
+		for _, close := range []float64{10.0, 20.0, 30.0, 40.0} {
+			// Window 1 with a range gives %K = 100 * (close - low) / (high - low)
+			stoch.AddHLCValue(close+10.0, close-10.0, close)
+		}
+
+		// %K is always 50, so %D must be 50 as well
+		output := stoch.GetStochOutput()
+		assert.Equal(t, 2, len(output))
+		assert.InDelta(t, 50.0, output[1].K, 0.0001)
+		assert.InDelta(t, 50.0, output[1].D, 0.0001)
+	})
+}
+
+// movingAverageInput is the series used for the moving average reference values
+var movingAverageInput = []float64{
+	10.0, 10.5, 11.2, 10.8, 11.5, 12.1, 11.9, 12.6, 13.0, 12.4,
+	12.9, 13.5, 14.1, 13.8, 14.4, 15.0, 14.6, 15.3, 15.9, 15.2,
+}
+
+func TestExtendedMovingAverages(t *testing.T) {
+	tests := []struct {
+		name      string
+		ma        MovingAverage
+		length    int
+		lastThree []float64
+	}{
+		{"WMA", NewWMA(5), 16, []float64{14.833333, 15.260000, 15.313333}},
+		{"DEMA", NewDEMA(5), 12, []float64{15.221037, 15.726811, 15.581954}},
+		{"TEMA", NewTEMA(5), 8, []float64{15.219274, 15.783365, 15.492339}},
+		{"HMA", NewHMA(5), 15, []float64{15.180000, 15.860000, 15.748889}},
+		{"ZLEMA", NewZLEMA(5), 14, []float64{15.115413, 15.810275, 15.573517}},
+		{"KAMA", NewKAMA(5, 2, 30), 15, []float64{14.009781, 14.483388, 14.519695}},
+		{"ALMA", NewALMA(5, 0.85, 6.0), 16, []float64{14.929918, 15.449444, 15.532170}},
+		{"T3", NewT3(3, 0.7), 8, []float64{14.957262, 15.364097, 15.484038}},
+		{"McGinley", NewMcGinley(5), 16, []float64{13.302245, 13.556776, 13.764735}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, value := range movingAverageInput {
+				tt.ma.AddValue(value)
+			}
+
+			output := tt.ma.GetOutput()
+			assert.Equal(t, tt.length, len(output))
+			for i, expected := range tt.lastThree {
+				assert.InDelta(t, expected, output[len(output)-3+i], 0.00001)
+			}
+
+			tt.ma.Reset()
+			assert.Empty(t, tt.ma.GetOutput())
+		})
+	}
+
+	t.Run("McGinley crossing zero", func(t *testing.T) {
+		mg := NewMcGinley(3)
+		for _, value := range []float64{3, 2, 1, 0, -1, -2, 1, 0, 0, 2} {
+			mg.AddValue(value)
+		}
+
+		output := mg.GetOutput()
+		assert.Equal(t, 8, len(output))
+		// A zero value takes the fastest step, all the way from the SMA seed of 2
+		assert.InDelta(t, 0.0, output[1], 0.00001)
+		for _, value := range output {
+			assert.False(t, math.IsNaN(value) || math.IsInf(value, 0))
+		}
+	})
+
+	t.Run("McGinley near zero", func(t *testing.T) {
+		mg := NewMcGinley(3)
+		values := []float64{3, 2, 1, 0.01, 0.02, 0.5, -0.3, 0.001, -0.002, 0.7, 1.5}
+		for _, value := range values {
+			mg.AddValue(value)
+		}
+
+		output := mg.GetOutput()
+		assert.Equal(t, len(values)-2, len(output))
+		// Every output lies between the previous output and the value
+		previous := output[0]
+		for i, value := range output[1:] {
+			input := values[i+3]
+			assert.True(t, value >= math.Min(previous, input) && value <= math.Max(previous, input),
+				"output %d = %v is outside [%v, %v]", i+1, value, previous, input)
+			previous = value
+		}
+	})
+
+	t.Run("Factory covers every type", func(t *testing.T) {
+		for maType := MATypeSMA; maType <= MATypeMcGinley; maType++ {
+			ma := NewMovingAverage(maType, 1)
+			assert.Equal(t, maType.String(), ma.GetName())
+		}
+	})
+}
+
+func TestVWMA(t *testing.T) {
+	t.Run("Basic VWMA calculation", func(t *testing.T) {
+		volumes := []float64{100, 120, 90, 150, 130, 110, 170, 160, 140, 120, 180, 150, 130, 190, 160, 140, 200, 170, 150, 180}
+		vwma := NewVWMA(5)
+		for i, value := range movingAverageInput {
+			vwma.AddValueVolume(value, volumes[i])
+		}
+
+		output := vwma.GetOutput()
+		assert.Equal(t, 16, len(output))
+		assert.InDelta(t, 14.589535, output[13], 0.00001)
+		assert.InDelta(t, 15.012195, output[14], 0.00001)
+		assert.InDelta(t, 15.169048, output[15], 0.00001)
+	})
+
+	t.Run("Unit volume gives the SMA", func(t *testing.T) {
+		vwma := NewVWMA(3)
+		for _, value := range []float64{1.0, 2.0, 3.0, 4.0} {
+			vwma.AddValue(value)
+		}
+		assert.Equal(t, []float64{2.0, 3.0}, vwma.GetOutput())
+	})
+}
+
+// testCandles is a synthetic series of {open, high, low, close, volume} candles
+// used for the OHLC indicator reference values
+var testCandles = [][5]float64{
+	{100.00, 100.23, 98.38, 99.36, 948},
+	{99.36, 100.95, 98.49, 100.81, 1839},
+	{100.81, 100.94, 99.08, 99.71, 1292},
+	{99.71, 100.35, 96.85, 98.09, 1053},
+	{98.09, 101.02, 97.22, 100.07, 926},
+	{100.07, 101.09, 98.61, 100.49, 895},
+	{100.49, 101.03, 99.86, 100.83, 1907},
+	{100.83, 101.29, 98.10, 99.32, 1170},
+	{99.32, 100.18, 97.47, 97.75, 999},
+	{97.75, 98.14, 97.66, 98.05, 1221},
+	{98.05, 98.93, 96.88, 98.13, 1753},
+	{98.13, 99.27, 97.68, 98.59, 1168},
+	{98.59, 99.90, 97.73, 99.53, 1875},
+	{99.53, 100.13, 98.86, 99.61, 949},
+	{99.61, 100.24, 96.97, 98.11, 1111},
+	{98.11, 100.66, 96.67, 100.03, 958},
+	{100.03, 102.10, 98.72, 101.24, 1442},
+	{101.24, 101.77, 99.92, 100.67, 1734},
+	{100.67, 100.81, 98.56, 98.96, 933},
+	{98.96, 100.01, 96.24, 97.21, 1712},
+	{97.21, 97.79, 95.41, 96.41, 846},
+	{96.41, 98.89, 95.49, 98.36, 1811},
+	{98.36, 99.51, 96.42, 96.61, 1307},
+	{96.61, 97.99, 95.54, 96.28, 1140},
+	{96.28, 97.10, 94.84, 96.17, 1681},
+	{96.17, 98.22, 95.55, 97.80, 1534},
+	{97.80, 99.24, 97.45, 98.67, 969},
+	{98.67, 99.02, 97.06, 97.41, 1793},
+	{97.41, 99.17, 96.99, 98.90, 1098},
+	{98.90, 99.45, 97.81, 98.66, 1057},
+	{98.66, 100.33, 97.73, 99.56, 910},
+	{99.56, 100.87, 98.05, 99.48, 1945},
+	{99.48, 100.08, 98.97, 99.13, 1620},
+	{99.13, 99.23, 97.08, 97.39, 1132},
+	{97.39, 98.29, 95.70, 95.85, 1960},
+	{95.85, 96.00, 93.94, 94.49, 852},
+	{94.49, 94.80, 92.23, 92.79, 1316},
+	{92.79, 95.70, 92.08, 94.80, 1036},
+	{94.80, 97.86, 94.10, 96.37, 1790},
+	{96.37, 96.59, 94.56, 95.68, 1342},
+}
+
+func TestADX(t *testing.T) {
+	t.Run("Basic ADX calculation", func(t *testing.T) {
+		adx := NewADX(5, 5)
+		for _, candle := range testCandles {
+			adx.AddOHLCValue(candle[1], candle[2], candle[3])
+		}
+
+		// +DI/-DI start after diLength changes, ADX after adxSmoothing more
+		assert.Equal(t, 35, len(adx.GetPlusDI()))
+		assert.Equal(t, 35, len(adx.GetMinusDI()))
+
+		output := adx.GetADXOutput()
+		assert.Equal(t, 31, len(output))
+		assert.InDelta(t, 44.051329, output[0].ADX, 0.00001)
+		assert.InDelta(t, 39.882462, output[1].ADX, 0.00001)
+
+		last := output[len(output)-1]
+		assert.InDelta(t, 29.717371, last.ADX, 0.00001)
+		assert.InDelta(t, 20.582267, last.PlusDI, 0.00001)
+		assert.InDelta(t, 20.367471, last.MinusDI, 0.00001)
+		assert.Equal(t, len(output), len(adx.GetOutput()))
+	})
+}
+
+func TestIchimoku(t *testing.T) {
+	t.Run("Lines carry their displaced bar index", func(t *testing.T) {
+		ich := NewIchimoku(2, 3, 4, 3, 2)
+		for _, candle := range testCandles[:6] {
+			ich.AddOHLCValue(candle[1], candle[2], candle[3])
+		}
+
+		tenkan := ich.GetTenkan()
+		assert.Equal(t, 5, len(tenkan))
+		assert.Equal(t, 1, tenkan[0].Index)
+		assert.InDelta(t, 99.665, tenkan[0].Value, 0.0001)
+		assert.Equal(t, ich.GetOutput()[4], tenkan[4].Value)
+
+		kijun := ich.GetKijun()
+		assert.Equal(t, 4, len(kijun))
+		assert.Equal(t, 2, kijun[0].Index)
+		assert.InDelta(t, 99.665, kijun[0].Value, 0.0001)
+
+		// Leading spans are plotted displacement bars ahead
+		senkouA := ich.GetSenkouA()
+		assert.Equal(t, 4, len(senkouA))
+		assert.Equal(t, 5, senkouA[0].Index)
+		assert.InDelta(t, (99.72+99.665)/2.0, senkouA[0].Value, 0.0001)
+
+		senkouB := ich.GetSenkouB()
+		assert.Equal(t, 3, len(senkouB))
+		assert.Equal(t, 6, senkouB[0].Index)
+		assert.Equal(t, 8, senkouB[2].Index)
+		assert.InDelta(t, 98.97, senkouB[2].Value, 0.0001)
+
+		// The lagging span is the close plotted in the past
+		chikou := ich.GetChikou()
+		assert.Equal(t, 6, len(chikou))
+		assert.Equal(t, -2, chikou[0].Index)
+		assert.Equal(t, 3, chikou[5].Index)
+		assert.InDelta(t, 100.49, chikou[5].Value, 0.0001)
+
+		output := ich.GetIchimokuOutput()
+		assert.Equal(t, 3, len(output))
+		last := output[2]
+		assert.Equal(t, 5, last.Tenkan.Index)
+		assert.InDelta(t, 99.155, last.Tenkan.Value, 0.0001)
+		assert.InDelta(t, 98.97, last.Kijun.Value, 0.0001)
+		assert.Equal(t, 8, last.SenkouA.Index)
+		assert.InDelta(t, (99.155+98.97)/2.0, last.SenkouA.Value, 0.0001)
+		assert.Equal(t, 3, last.Chikou.Index)
+	})
+}
+
+// flipIndexes returns the positions of the outputs that reversed the trend
+func flipIndexes(outputs []TrailingStopOutput) []int {
+	flips := make([]int, 0)
+	for i, output := range outputs {
+		if output.Flipped {
+			flips = append(flips, i)
+		}
+	}
+	return flips
+}
+
+func TestPSAR(t *testing.T) {
+	t.Run("Basic Parabolic SAR calculation", func(t *testing.T) {
+		psar := NewPSAR(0.02, 0.02, 0.2)
+		for _, candle := range testCandles {
+			psar.AddOHLCValue(candle[1], candle[2], candle[3])
+		}
+
+		output := psar.GetPSAROutput()
+		assert.Equal(t, 39, len(output))
+		assert.Equal(t, []int{2, 3, 9, 15, 18, 28, 33}, flipIndexes(output))
+
+		// Starts long below the first two lows
+		assert.InDelta(t, 98.38, output[0].Stop, 0.0001)
+		assert.Equal(t, TrendUp, output[0].Trend)
+
+		// On a reversal the SAR jumps to the previous extreme
+		assert.InDelta(t, 100.95, output[2].Stop, 0.0001)
+		assert.Equal(t, TrendDown, output[2].Trend)
+		assert.InDelta(t, 96.85, output[3].Stop, 0.0001)
+		assert.Equal(t, TrendUp, output[3].Trend)
+
+		assert.InDelta(t, 97.0196, output[5].Stop, 0.0001)
+		assert.InDelta(t, 98.781561, output[38].Stop, 0.00001)
+		assert.Equal(t, TrendDown, output[38].Trend)
+		assert.Equal(t, output[38].Stop, psar.GetOutput()[38])
+	})
+}
+
+func TestSuperTrend(t *testing.T) {
+	t.Run("Basic SuperTrend calculation", func(t *testing.T) {
+		st := NewSuperTrend(5, 1.0)
+		for _, candle := range testCandles {
+			st.AddOHLCValue(candle[1], candle[2], candle[3])
+		}
+
+		output := st.GetSuperTrendOutput()
+		assert.Equal(t, 36, len(output))
+		assert.Equal(t, []int{11, 15, 22, 30, 34}, flipIndexes(output))
+
+		// Starts on the short side at the upper band
+		assert.InDelta(t, 101.814, output[0].Stop, 0.0001)
+		assert.Equal(t, TrendDown, output[0].Trend)
+
+		// After flipping up the stop follows the lower band
+		assert.InDelta(t, 97.602317, output[11].Stop, 0.00001)
+		assert.Equal(t, TrendUp, output[11].Trend)
+		assert.InDelta(t, 97.707986, output[12].Stop, 0.00001)
+		assert.False(t, output[12].Flipped)
+
+		assert.InDelta(t, 93.195828, output[35].Stop, 0.00001)
+		assert.Equal(t, TrendUp, output[35].Trend)
+	})
+}
+
+// testCandleStream converts testCandles into OHLCV candles one minute apart
+func testCandleStream() []*ohlcv.OHLCV {
+	start := time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC)
+	candles := make([]*ohlcv.OHLCV, len(testCandles))
+	for i, c := range testCandles {
+		candles[i] = ohlcv.NewOHLCV(start.Add(time.Duration(i)*time.Minute), c[0], c[1], c[2], c[3], c[4])
+	}
+	return candles
+}
+
+func TestVolumeIndicators(t *testing.T) {
+	tests := []struct {
+		name      string
+		indicator CandleIndicator
+		length    int
+		first     float64
+		last      float64
+	}{
+		{"OBV", NewOBV(), 40, 0.0, -3914.0},
+		{"AccDist", NewAccDist(), 40, 56.367568, 1591.148696},
+		{"CMF", NewCMF(5), 36, 0.235293, -0.017127},
+		{"ChaikinOsc", NewChaikinOsc(3, 10), 31, 768.123536, -804.200200},
+		{"MFI", NewMFI(5), 35, 61.109091, 44.828110},
+		{"ForceIndex", NewForceIndex(5), 35, 349.774, 133.526380},
+		{"EMV", NewEMV(5, 10000), 35, 0.885448, -5.299425},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, candle := range testCandleStream() {
+				tt.indicator.AddCandle(candle)
+			}
+
+			output := tt.indicator.GetOutput()
+			assert.Equal(t, tt.length, len(output))
+			assert.InDelta(t, tt.first, output[0], 0.00001)
+			assert.InDelta(t, tt.last, output[len(output)-1], 0.00001)
+
+			tt.indicator.Reset()
+			assert.Empty(t, tt.indicator.GetOutput())
+		})
+	}
+
+	t.Run("MFI without negative flow", func(t *testing.T) {
+		mfi := NewMFI(2)
+		for _, close := range []float64{1.0, 2.0, 3.0} {
+			mfi.AddHLCVValue(close, close, close, 10.0)
+		}
+		assert.Equal(t, []float64{100.0}, mfi.GetOutput())
+	})
+}
+
+func TestVWAP(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+
+	// Candles with high = low = close so the typical price is the close
+	candle := func(ts time.Time, price, volume float64) *ohlcv.OHLCV {
+		return ohlcv.NewOHLCV(ts, price, price, price, price, volume)
+	}
+
+	t.Run("Session VWAP restarts at the session open", func(t *testing.T) {
+		vwap := NewVWAP(WithVWAPSession(newYork, 9*time.Hour+30*time.Minute), WithVWAPBands(1.0))
+
+		// Daylight saving time starts on 2024-03-10
+		day1 := time.Date(2024, 3, 9, 10, 0, 0, 0, newYork)
+		vwap.AddCandle(candle(day1, 10.0, 100))
+		vwap.AddCandle(candle(day1.Add(time.Hour), 20.0, 300))
+		// Pre-market of the next day still belongs to the previous session
+		vwap.AddCandle(candle(time.Date(2024, 3, 10, 8, 0, 0, 0, newYork), 30.0, 100))
+		// Session open given in UTC, after the daylight saving change
+		vwap.AddCandle(candle(time.Date(2024, 3, 10, 13, 30, 0, 0, time.UTC), 40.0, 100))
+
+		output := vwap.GetVWAPOutput()
+		assert.Equal(t, 4, len(output))
+		assert.InDelta(t, 10.0, output[0].VWAP, 0.0001)
+		assert.InDelta(t, 17.5, output[1].VWAP, 0.0001)
+		assert.InDelta(t, 20.0, output[2].VWAP, 0.0001)
+		assert.InDelta(t, 40.0, output[3].VWAP, 0.0001)
+
+		// Weighted variance of {10 x 100, 20 x 300}: 0.25 * 7.5^2 + 0.75 * 2.5^2 = 18.75
+		assert.InDelta(t, math.Sqrt(18.75), output[1].StdDev, 0.0001)
+		assert.InDelta(t, 17.5+math.Sqrt(18.75), output[1].Upper[0], 0.0001)
+		assert.InDelta(t, 17.5-math.Sqrt(18.75), output[1].Lower[0], 0.0001)
+		assert.InDelta(t, 0.0, output[3].StdDev, 0.0001)
+
+		// The bands returned are copies
+		output[1].Upper[0] = 0
+		output[1].Lower[0] = 0
+		assert.InDelta(t, 17.5+math.Sqrt(18.75), vwap.GetVWAPOutput()[1].Upper[0], 0.0001)
+		assert.InDelta(t, 17.5-math.Sqrt(18.75), vwap.GetVWAPOutput()[1].Lower[0], 0.0001)
+	})
+
+	t.Run("Anchored VWAP can be re-anchored", func(t *testing.T) {
+		vwap := NewAnchoredVWAP()
+		start := time.Date(2024, 3, 8, 10, 0, 0, 0, time.UTC)
+
+		vwap.AddCandle(candle(start, 10.0, 100))
+		// Anchored VWAPs ignore session boundaries
+		vwap.AddCandle(candle(start.Add(24*time.Hour), 20.0, 100))
+		assert.InDelta(t, 15.0, vwap.GetOutput()[1], 0.0001)
+
+		vwap.Anchor()
+		vwap.AddCandle(candle(start.Add(48*time.Hour), 30.0, 100))
+		assert.InDelta(t, 30.0, vwap.GetOutput()[2], 0.0001)
+
+		// Anchor on a future event
+		vwap.AnchorAt(start.Add(96 * time.Hour))
+		vwap.AddCandle(candle(start.Add(72*time.Hour), 50.0, 100))
+		assert.InDelta(t, 40.0, vwap.GetOutput()[3], 0.0001)
+		vwap.AddCandle(candle(start.Add(96*time.Hour), 70.0, 100))
+		assert.InDelta(t, 70.0, vwap.GetOutput()[4], 0.0001)
+
+		output := vwap.GetVWAPOutput()
+		assert.Equal(t, 2, len(output[0].Upper))
+	})
+}
+
+// assertChannel checks a channel output against expected upper, middle and lower values
+func assertChannel(t *testing.T, expected [3]float64, actual ChannelOutput) {
+	assert.InDelta(t, expected[0], actual.Upper, 0.00001)
+	assert.InDelta(t, expected[1], actual.Middle, 0.00001)
+	assert.InDelta(t, expected[2], actual.Lower, 0.00001)
+}
+
+func TestChannels(t *testing.T) {
+	t.Run("Keltner Channels", func(t *testing.T) {
+		kc := NewKeltner(5, 3, 2.0)
+		for _, candle := range testCandles {
+			kc.AddOHLCValue(candle[1], candle[2], candle[3])
+		}
+
+		output := kc.GetChannelOutput()
+		assert.Equal(t, 36, len(output))
+		assertChannel(t, [3]float64{105.525037, 99.608, 93.690963}, output[0])
+		assertChannel(t, [3]float64{101.052372, 95.591350, 90.130328}, output[35])
+		assert.Equal(t, kc.GetMiddleBand(), kc.GetOutput())
+	})
+
+	t.Run("Donchian Channels", func(t *testing.T) {
+		dc := NewDonchian(5)
+		for _, candle := range testCandles {
+			dc.AddOHLCValue(candle[1], candle[2], candle[3])
+		}
+
+		output := dc.GetChannelOutput()
+		assert.Equal(t, 36, len(output))
+		assertChannel(t, [3]float64{101.02, 98.935, 96.85}, output[0])
+		assertChannel(t, [3]float64{97.86, 94.97, 92.08}, output[35])
+	})
+
+	t.Run("Chandelier Exit", func(t *testing.T) {
+		ce := NewChandelier(5, 3.0)
+		for _, candle := range testCandles {
+			ce.AddOHLCValue(candle[1], candle[2], candle[3])
+		}
+
+		output := ce.GetChannelOutput()
+		assert.Equal(t, 36, len(output))
+		assertChannel(t, [3]float64{104.932, 98.935, 92.938}, output[0])
+		assertChannel(t, [3]float64{99.980013, 94.97, 89.959987}, output[35])
+		assert.Equal(t, ce.GetLowerBand(), ce.GetLongExit())
+		assert.Equal(t, ce.GetUpperBand(), ce.GetShortExit())
+	})
+
+	t.Run("Envelopes", func(t *testing.T) {
+		env := NewEnvelopes(5, 2.5)
+		for _, candle := range testCandles {
+			env.AddValue(candle[3])
+		}
+
+		output := env.GetChannelOutput()
+		assert.Equal(t, 36, len(output))
+		assertChannel(t, [3]float64{97.19665, 94.826, 92.45535}, output[35])
+	})
+
+	t.Run("Bollinger Bands %B and bandwidth", func(t *testing.T) {
+		bb := NewBBands(5, 2.0)
+		for _, candle := range testCandles {
+			bb.AddValue(candle[3])
+		}
+
+		percentB := bb.GetPercentB()
+		bandwidth := bb.GetBandwidth()
+		assert.Equal(t, 36, len(percentB))
+		assert.Equal(t, 36, len(bandwidth))
+		assert.InDelta(t, 0.628580, percentB[0], 0.00001)
+		assert.InDelta(t, 0.036072, bandwidth[0], 0.00001)
+		assert.InDelta(t, 0.675827, percentB[35], 0.00001)
+		assert.InDelta(t, 0.051220, bandwidth[35], 0.00001)
+		assert.Equal(t, bb.GetChannelOutput(), bb.GetBBandsOutput())
+	})
+}
+
+func TestOscillators(t *testing.T) {
+	t.Run("CCI", func(t *testing.T) {
+		cci := NewCCI(5)
+		for _, candle := range testCandles {
+			cci.AddOHLCValue(candle[1], candle[2], candle[3])
+		}
+
+		output := cci.GetOutput()
+		assert.Equal(t, 36, len(output))
+		assert.InDelta(t, 61.241897, output[1], 0.00001)
+		assert.InDelta(t, 99.051008, output[2], 0.00001)
+		assert.InDelta(t, 63.372941, output[35], 0.00001)
+	})
+
+	t.Run("Williams %R", func(t *testing.T) {
+		wr := NewWilliamsR(5)
+		for _, candle := range testCandles {
+			wr.AddOHLCValue(candle[1], candle[2], candle[3])
+		}
+
+		output := wr.GetOutput()
+		assert.Equal(t, 36, len(output))
+		assert.InDelta(t, -22.781775, output[0], 0.00001)
+		assert.InDelta(t, -37.716263, output[35], 0.00001)
+	})
+
+	t.Run("Stochastic RSI", func(t *testing.T) {
+		srsi := NewStochRSI(5, 5, 3, 3)
+		for _, candle := range testCandles {
+			srsi.AddValue(candle[3])
+		}
+
+		output := srsi.GetStochOutput()
+		assert.Equal(t, 28, len(output))
+		assert.InDelta(t, 72.687574, output[0].K, 0.00001)
+		assert.InDelta(t, 42.426337, output[0].D, 0.00001)
+		assert.InDelta(t, 95.113026, output[27].K, 0.00001)
+		assert.InDelta(t, 65.037675, output[27].D, 0.00001)
+		assert.Equal(t, 28, len(srsi.GetOutput()))
+		assert.InDelta(t, 95.113026, srsi.GetOutput()[27], 0.00001)
+	})
+
+	t.Run("Ultimate Oscillator", func(t *testing.T) {
+		uo := NewUltimateOsc(2, 3, 5)
+		for _, candle := range testCandles {
+			uo.AddOHLCValue(candle[1], candle[2], candle[3])
+		}
+
+		output := uo.GetOutput()
+		assert.Equal(t, 35, len(output))
+		assert.InDelta(t, 69.517455, output[0], 0.00001)
+		assert.InDelta(t, 59.354749, output[34], 0.00001)
+	})
+
+	t.Run("CMO", func(t *testing.T) {
+		cmo := NewCMO(5)
+		for _, candle := range testCandles {
+			cmo.AddValue(candle[3])
+		}
+
+		output := cmo.GetOutput()
+		assert.Equal(t, 35, len(output))
+		assert.InDelta(t, 17.199391, output[0], 0.00001)
+		assert.InDelta(t, -2.319236, output[34], 0.00001)
+	})
+}
+
+func TestMomentumIndicators(t *testing.T) {
+	tests := []struct {
+		name      string
+		indicator ValueIndicator
+		length    int
+		first     float64
+		last      float64
+	}{
+		{"ROC", NewROC(5), 35, 1.137279, -0.177360},
+		{"Momentum", NewMomentum(5), 35, 1.13, -0.17},
+		{"TRIX", NewTRIX(4), 30, -0.299312, -0.283026},
+		{"APO", NewAPO(3, 6), 35, 0.26375, -0.115409},
+		{"TSI", NewTSI(6, 3), 32, -17.150865, -5.624037},
+		{"KST", NewKST([4]int{2, 3, 4, 5}, [4]int{2, 2, 2, 3}, 3), 33, 6.428588, 2.743341},
+		{"Coppock", NewCoppock(6, 4, 4), 31, -2.057319, -3.663417},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, candle := range testCandles {
+				tt.indicator.AddValue(candle[3])
+			}
+
+			output := tt.indicator.GetOutput()
+			assert.Equal(t, tt.length, len(output))
+			assert.InDelta(t, tt.first, output[0], 0.00001)
+			assert.InDelta(t, tt.last, output[len(output)-1], 0.00001)
+
+			tt.indicator.Reset()
+			assert.Empty(t, tt.indicator.GetOutput())
+		})
+	}
+
+	t.Run("PPO", func(t *testing.T) {
+		ppo := NewPPO(3, 6, 3)
+		for _, candle := range testCandles {
+			ppo.AddValue(candle[3])
+		}
+
+		assert.Equal(t, 35, len(ppo.GetPPOLine()))
+		output := ppo.GetPPOOutput()
+		assert.Equal(t, 33, len(output))
+		last := output[len(output)-1]
+		assert.InDelta(t, -0.120615, last.PPO, 0.00001)
+		assert.InDelta(t, -0.365710, last.Signal, 0.00001)
+		assert.InDelta(t, 0.245095, last.Histogram, 0.00001)
+	})
+
+	t.Run("KST signal line", func(t *testing.T) {
+		kst := NewKST([4]int{2, 3, 4, 5}, [4]int{2, 2, 2, 3}, 3)
+		for _, candle := range testCandles {
+			kst.AddValue(candle[3])
+		}
+
+		output := kst.GetKSTOutput()
+		assert.Equal(t, 31, len(output))
+		assert.InDelta(t, 2.743341, output[30].KST, 0.00001)
+		assert.InDelta(t, -18.681938, output[30].Signal, 0.00001)
+	})
+
+	t.Run("Chain of indicators", func(t *testing.T) {
+		// A chain reproduces TRIX
+		chain := NewChain(NewEMA(4), NewEMA(4), NewEMA(4), NewROC(1))
+		trix := NewTRIX(4)
+		for _, candle := range testCandles {
+			chain.AddValue(candle[3])
+			trix.AddValue(candle[3])
+		}
+		assert.Equal(t, trix.GetOutput(), chain.GetOutput())
+	})
+
+	t.Run("Chain feeds swings once", func(t *testing.T) {
+		// The SMA only receives a value when the ZigZag confirms a swing
+		zigzag := NewZigZag(5)
+		chain := NewChain(NewZigZag(5), NewSMA(1))
+		for _, candle := range testCandles {
+			zigzag.AddValue(candle[3])
+			chain.AddValue(candle[3])
+		}
+		assert.NotEmpty(t, zigzag.GetOutput())
+		assert.Equal(t, zigzag.GetOutput(), chain.GetOutput())
+	})
+
+	t.Run("Chain rejects multi output indicators", func(t *testing.T) {
+		assert.Panics(t, func() { NewChain(NewMACD(3, 5, 2), NewSMA(2)) })
+		assert.Panics(t, func() { NewChain(NewSMA(2), NewStoch(5, 3, 3)) })
+	})
+}
+
+func TestTrendIndicators(t *testing.T) {
+	t.Run("Aroon", func(t *testing.T) {
+		aroon := NewAroon(5)
+		for _, candle := range testCandles {
+			aroon.AddOHLCValue(candle[1], candle[2], candle[3])
+		}
+
+		output := aroon.GetAroonOutput()
+		assert.Equal(t, 35, len(output))
+		assert.Equal(t, len(output), len(aroon.GetOutput()))
+		assert.InDelta(t, 100.0, output[0].Up, 0.00001)
+		assert.InDelta(t, 60.0, output[0].Down, 0.00001)
+		assert.InDelta(t, 0.0, output[34].Up, 0.00001)
+		assert.InDelta(t, 60.0, output[34].Down, 0.00001)
+		assert.InDelta(t, -60.0, output[34].Oscillator, 0.00001)
+		assert.InDelta(t, -60.0, aroon.GetOutput()[34], 0.00001)
+	})
+
+	t.Run("Vortex", func(t *testing.T) {
+		vortex := NewVortex(5)
+		for _, candle := range testCandles {
+			vortex.AddOHLCValue(candle[1], candle[2], candle[3])
+		}
+
+		output := vortex.GetVortexOutput()
+		assert.Equal(t, 35, len(output))
+		assert.InDelta(t, 1.016312, output[0].PlusVI, 0.00001)
+		assert.InDelta(t, 0.939007, output[0].MinusVI, 0.00001)
+		assert.InDelta(t, 0.918803, output[34].PlusVI, 0.00001)
+		assert.InDelta(t, 1.121083, output[34].MinusVI, 0.00001)
+	})
+
+	t.Run("Choppiness", func(t *testing.T) {
+		chop := NewChoppiness(5)
+		for _, candle := range testCandles {
+			chop.AddOHLCValue(candle[1], candle[2], candle[3])
+		}
+
+		output := chop.GetOutput()
+		assert.Equal(t, 36, len(output))
+		assert.InDelta(t, 72.854563, output[0], 0.00001)
+		assert.InDelta(t, 55.143893, output[35], 0.00001)
+	})
+
+	t.Run("Reset", func(t *testing.T) {
+		aroon := NewAroon(5)
+		vortex := NewVortex(5)
+		chop := NewChoppiness(5)
+		for i := 0; i < 2; i++ {
+			for _, candle := range testCandles {
+				aroon.AddOHLCValue(candle[1], candle[2], candle[3])
+				vortex.AddOHLCValue(candle[1], candle[2], candle[3])
+				chop.AddOHLCValue(candle[1], candle[2], candle[3])
+			}
+			if i == 0 {
+				aroon.Reset()
+				vortex.Reset()
+				chop.Reset()
+			}
+		}
+		aroonOutput := aroon.GetOutput()
+		assert.Equal(t, 35, len(aroonOutput))
+		assert.InDelta(t, -60.0, aroonOutput[34], 0.00001)
+		vortexOutput := vortex.GetVortexOutput()
+		assert.Equal(t, 35, len(vortexOutput))
+		assert.InDelta(t, 0.918803, vortexOutput[34].PlusVI, 0.00001)
+		chopOutput := chop.GetOutput()
+		assert.Equal(t, 36, len(chopOutput))
+		assert.InDelta(t, 55.143893, chopOutput[35], 0.00001)
+	})
+}
+
+func TestRollingStatisticsIndicators(t *testing.T) {
+	closes := make([]float64, len(testCandles))
+	for i, candle := range testCandles {
+		closes[i] = candle[3]
+	}
+	feed := func(indicator Indicator) {
+		for _, value := range closes {
+			indicator.AddValue(value)
+		}
+	}
+
+	t.Run("StdDev and Variance", func(t *testing.T) {
+		sd := NewStdDev(5)
+		sampleSD := NewStdDev(5, WithStdDevSample())
+		variance := NewVariance(5)
+		sampleVariance := NewVariance(5, WithVarianceSample())
+		feed(sd)
+		feed(sampleSD)
+		feed(variance)
+		feed(sampleVariance)
+
+		output := sd.GetOutput()
+		assert.Equal(t, 36, len(output))
+		assert.InDelta(t, 0.898274, output[0], 0.00001)
+		assert.InDelta(t, 1.214259, output[35], 0.00001)
+		assert.InDelta(t, 1.357582, sampleSD.GetOutput()[35], 0.00001)
+		assert.InDelta(t, 1.474424, variance.GetOutput()[35], 0.00001)
+		assert.InDelta(t, 1.843030, sampleVariance.GetOutput()[35], 0.00001)
+	})
+
+	t.Run("ZScore", func(t *testing.T) {
+		zScore := NewZScore(5)
+		feed(zScore)
+
+		output := zScore.GetOutput()
+		assert.Equal(t, 36, len(output))
+		assert.InDelta(t, 0.514320, output[0], 0.00001)
+		assert.InDelta(t, 0.703310, output[35], 0.00001)
+
+		flat := NewZScore(3)
+		for i := 0; i < 5; i++ {
+			flat.AddValue(10)
+		}
+		assert.Equal(t, []float64{0, 0, 0}, flat.GetOutput())
+	})
+
+	t.Run("Median", func(t *testing.T) {
+		odd := NewMedian(5)
+		even := NewMedian(4)
+		feed(odd)
+		feed(even)
+
+		output := odd.GetOutput()
+		assert.Equal(t, 36, len(output))
+		assert.InDelta(t, 99.71, output[0], 0.00001)
+		assert.InDelta(t, 94.8, output[35], 0.00001)
+
+		output = even.GetOutput()
+		assert.Equal(t, 37, len(output))
+		assert.InDelta(t, 99.535, output[0], 0.00001)
+		assert.InDelta(t, 95.24, output[36], 0.00001)
+
+		// Windows holding a NaN have no median, later windows are unaffected
+		median := NewMedian(3)
+		for _, value := range []float64{1, math.NaN(), 2, 3, 4} {
+			median.AddValue(value)
+		}
+		output = median.GetOutput()
+		assert.Len(t, output, 3)
+		assert.True(t, math.IsNaN(output[0]))
+		assert.True(t, math.IsNaN(output[1]))
+		assert.Equal(t, 3.0, output[2])
+	})
+
+	t.Run("PercentRank", func(t *testing.T) {
+		pr := NewPercentRank(5)
+		feed(pr)
+
+		output := pr.GetOutput()
+		assert.Equal(t, 35, len(output))
+		assert.Equal(t, []float64{80, 100, 20, 0, 20, 40, 60, 100}, output[:8])
+		assert.InDelta(t, 60.0, output[34], 0.00001)
+
+		pr = NewPercentRank(2)
+		for _, value := range []float64{1, math.NaN(), 2, 3, 4, math.NaN()} {
+			pr.AddValue(value)
+		}
+		output = pr.GetOutput()
+		assert.Len(t, output, 4)
+		assert.True(t, math.IsNaN(output[0]))
+		assert.True(t, math.IsNaN(output[1]))
+		assert.Equal(t, 100.0, output[2])
+		assert.True(t, math.IsNaN(output[3]))
+	})
+
+	t.Run("RollingMax and RollingMin", func(t *testing.T) {
+		rollingMax := NewRollingMax(5)
+		rollingMin := NewRollingMin(5)
+		feed(rollingMax)
+		feed(rollingMin)
+
+		assert.Equal(t, 36, len(rollingMax.GetOutput()))
+		assert.Equal(t, 36, len(rollingMin.GetOutput()))
+		assert.Equal(t, 96.37, rollingMax.GetOutput()[35])
+		assert.Equal(t, 92.79, rollingMin.GetOutput()[35])
+	})
+
+	t.Run("Chained onto another indicator", func(t *testing.T) {
+		// Volatility of the one-bar rate of change
+		chain := NewChain(NewROC(1), NewStdDev(5))
+		feed(chain)
+
+		output := chain.GetOutput()
+		assert.Equal(t, 35, len(output))
+		assert.InDelta(t, 1.624520, output[34], 0.00001)
+	})
+
+	t.Run("Reset", func(t *testing.T) {
+		indicators := []IndicatorWithWindow{
+			NewStdDev(5), NewVariance(5), NewZScore(5), NewMedian(5),
+			NewPercentRank(5), NewRollingMax(5), NewRollingMin(5),
+		}
+		for _, indicator := range indicators {
+			feed(indicator)
+			expected := indicator.GetOutput()
+			indicator.Reset()
+			assert.Empty(t, indicator.GetOutput())
+			feed(indicator)
+			assert.Equal(t, expected, indicator.GetOutput())
+			assert.Equal(t, 5, indicator.GetWindowSize())
+		}
+	})
+}
+
+func TestLinearRegression(t *testing.T) {
+	closes := make([]float64, len(testCandles))
+	for i, candle := range testCandles {
+		closes[i] = candle[3]
+	}
+
+	t.Run("LinReg", func(t *testing.T) {
+		lr := NewLinReg(10)
+		for _, value := range closes {
+			lr.AddValue(value)
+		}
+
+		output := lr.GetLinRegOutput()
+		assert.Equal(t, 31, len(output))
+		assert.Equal(t, 31, len(lr.GetOutput()))
+		assert.InDelta(t, 98.724727, output[0].Value, 0.00001)
+		assert.InDelta(t, -0.160727, output[0].Slope, 0.00001)
+		assert.InDelta(t, 100.171273, output[0].Intercept, 0.00001)
+		assert.InDelta(t, 0.177424, output[0].RSquared, 0.00001)
+		assert.InDelta(t, 1.111357, output[0].StdErr, 0.00001)
+		assert.InDelta(t, 94.004, output[30].Value, 0.00001)
+		assert.InDelta(t, -0.566667, output[30].Slope, 0.00001)
+		assert.InDelta(t, 99.104, output[30].Intercept, 0.00001)
+		assert.InDelta(t, 0.555034, output[30].RSquared, 0.00001)
+		assert.InDelta(t, 1.629347, output[30].StdErr, 0.00001)
+		assert.InDelta(t, 94.004, lr.GetOutput()[30], 0.00001)
+	})
+
+	t.Run("Base output selection", func(t *testing.T) {
+		slope := NewLinRegSlope(10)
+		intercept := NewLinRegIntercept(10)
+		rSquared := NewLinRegRSquared(10)
+		stdErr := NewLinRegStdErr(10)
+		for _, value := range closes {
+			slope.AddValue(value)
+			intercept.AddValue(value)
+			rSquared.AddValue(value)
+			stdErr.AddValue(value)
+		}
+
+		assert.Equal(t, slope.GetSlope(), slope.GetOutput())
+		assert.Equal(t, intercept.GetIntercept(), intercept.GetOutput())
+		assert.Equal(t, rSquared.GetRSquared(), rSquared.GetOutput())
+		assert.Equal(t, stdErr.GetStdErr(), stdErr.GetOutput())
+		assert.InDelta(t, -0.566667, slope.GetOutput()[30], 0.00001)
+	})
+
+	t.Run("Chained onto another indicator", func(t *testing.T) {
+		chain := NewChain(NewEMA(3), NewLinRegSlope(10))
+		for _, value := range closes {
+			chain.AddValue(value)
+		}
+		assert.Equal(t, 29, len(chain.GetOutput()))
+	})
+
+	t.Run("Perfect line and flat series", func(t *testing.T) {
+		lr := NewLinReg(5)
+		for i := 0; i < 8; i++ {
+			lr.AddValue(3.0 + 2.0*float64(i))
+		}
+		last := lr.GetLinRegOutput()[3]
+		assert.InDelta(t, 17.0, last.Value, 1e-9)
+		assert.InDelta(t, 2.0, last.Slope, 1e-9)
+		assert.InDelta(t, 9.0, last.Intercept, 1e-9)
+		assert.InDelta(t, 1.0, last.RSquared, 1e-9)
+		assert.InDelta(t, 0.0, last.StdErr, 1e-6)
+
+		flat := NewLinReg(5)
+		for i := 0; i < 6; i++ {
+			flat.AddValue(7.0)
+		}
+		assert.Equal(t, LinRegOutput{Value: 7, Intercept: 7}, flat.GetLinRegOutput()[1])
+	})
+
+	t.Run("Long series stays accurate", func(t *testing.T) {
+		const windowSize = 50
+		lr := NewLinReg(windowSize)
+		values := make([]float64, 200000)
+		price := 20000.0
+		for i := range values {
+			price += math.Sin(float64(i)*0.37) * 3
+			values[i] = price
+			lr.AddValue(price)
+		}
+
+		// Reference fit of the last window computed from scratch
+		window := values[len(values)-windowSize:]
+		meanX := float64(windowSize-1) / 2.0
+		var meanY float64
+		for _, v := range window {
+			meanY += v
+		}
+		meanY /= windowSize
+		var sxy, sxx float64
+		for i, v := range window {
+			sxy += (float64(i) - meanX) * (v - meanY)
+			sxx += (float64(i) - meanX) * (float64(i) - meanX)
+		}
+		slope := sxy / sxx
+
+		last := lr.GetLinRegOutput()[len(values)-windowSize]
+		assert.InDelta(t, slope, last.Slope, 1e-7)
+		assert.InDelta(t, meanY+slope*meanX, last.Value, 1e-6)
+	})
+
+	t.Run("LinRegChannel", func(t *testing.T) {
+		lrc := NewLinRegChannel(10, 2.0)
+		for _, value := range closes {
+			lrc.AddValue(value)
+		}
+
+		output := lrc.GetChannelOutput()
+		assert.Equal(t, 31, len(output))
+		assert.InDelta(t, 97.262694, output[30].Upper, 0.00001)
+		assert.InDelta(t, 94.004, output[30].Middle, 0.00001)
+		assert.InDelta(t, 90.745306, output[30].Lower, 0.00001)
+
+		lrc.Reset()
+		assert.Empty(t, lrc.GetOutput())
+		assert.Empty(t, lrc.GetChannelOutput())
+	})
+}
+
+func TestPairIndicators(t *testing.T) {
+	// The close is paired with the median price of the same candle
+	feed := func(indicator PairIndicator) {
+		for _, candle := range testCandles {
+			indicator.AddPairValue(candle[3], (candle[1]+candle[2])/2.0)
+		}
+	}
+
+	t.Run("Correlation, Covariance and Beta", func(t *testing.T) {
+		corr := NewCorrelation(8)
+		cov := NewCovariance(8)
+		sampleCov := NewCovariance(8, WithCovarianceSample())
+		beta := NewBeta(8)
+		for _, indicator := range []PairIndicator{corr, cov, sampleCov, beta} {
+			feed(indicator)
+			assert.Equal(t, 33, len(indicator.GetOutput()))
+		}
+
+		assert.InDelta(t, 0.755969, corr.GetOutput()[0], 0.00001)
+		assert.InDelta(t, 0.944275, corr.GetOutput()[32], 0.00001)
+		assert.InDelta(t, 0.349209, cov.GetOutput()[0], 0.00001)
+		assert.InDelta(t, 3.273205, cov.GetOutput()[32], 0.00001)
+		assert.InDelta(t, 3.740805, sampleCov.GetOutput()[32], 0.00001)
+		assert.InDelta(t, 1.222563, beta.GetOutput()[0], 0.00001)
+		assert.InDelta(t, 0.876267, beta.GetOutput()[32], 0.00001)
+	})
+
+	t.Run("Perfectly related series", func(t *testing.T) {
+		corr := NewCorrelation(5)
+		beta := NewBeta(5)
+		for i := 0; i < 10; i++ {
+			benchmark := math.Sin(float64(i))
+			corr.AddPairValue(-3*benchmark+1, benchmark)
+			beta.AddPairValue(-3*benchmark+1, benchmark)
+		}
+		assert.InDelta(t, -1.0, corr.GetOutput()[5], 1e-12)
+		assert.InDelta(t, -3.0, beta.GetOutput()[5], 1e-9)
+
+		flat := NewCorrelation(3)
+		for i := 0; i < 4; i++ {
+			flat.AddPairValue(float64(i), 5)
+		}
+		assert.Equal(t, []float64{0, 0}, flat.GetOutput())
+	})
+
+	t.Run("Long series stays accurate", func(t *testing.T) {
+		const windowSize = 30
+		corr := NewCorrelation(windowSize)
+		first := make([]float64, 100000)
+		second := make([]float64, 100000)
+		for i := range first {
+			first[i] = 10000 + 50*math.Sin(float64(i)*0.1)
+			second[i] = 5000 + 20*math.Sin(float64(i)*0.1+0.5)
+			corr.AddPairValue(first[i], second[i])
+		}
+
+		x := first[len(first)-windowSize:]
+		y := second[len(second)-windowSize:]
+		var meanX, meanY float64
+		for i := range x {
+			meanX += x[i] / windowSize
+			meanY += y[i] / windowSize
+		}
+		var sxy, sxx, syy float64
+		for i := range x {
+			sxy += (x[i] - meanX) * (y[i] - meanY)
+			sxx += (x[i] - meanX) * (x[i] - meanX)
+			syy += (y[i] - meanY) * (y[i] - meanY)
+		}
+		output := corr.GetOutput()
+		assert.InDelta(t, sxy/math.Sqrt(sxx*syy), output[len(output)-1], 1e-7)
+	})
+
+	t.Run("Spread, Ratio and SpreadZScore", func(t *testing.T) {
+		spread := NewSpread(0.9)
+		ratio := NewRatio()
+		spreadZScore := NewSpreadZScore(8, 0.9)
+		for _, indicator := range []PairIndicator{spread, ratio, spreadZScore} {
+			feed(indicator)
+		}
+
+		assert.Equal(t, 40, len(spread.GetOutput()))
+		assert.InDelta(t, 9.6625, spread.GetOutput()[39], 0.00001)
+		assert.Equal(t, 40, len(ratio.GetOutput()))
+		assert.InDelta(t, 1.001099, ratio.GetOutput()[39], 0.00001)
+		output := spreadZScore.GetOutput()
+		assert.Equal(t, 33, len(output))
+		assert.InDelta(t, -1.025725, output[0], 0.00001)
+		assert.InDelta(t, 0.537147, output[32], 0.00001)
+
+		spreadZScore.Reset()
+		assert.Empty(t, spreadZScore.GetOutput())
+		feed(spreadZScore)
+		assert.Equal(t, output, spreadZScore.GetOutput())
+	})
+
+	t.Run("Aligned streams", func(t *testing.T) {
+		asset := testCandleStream()
+		benchmark := make([]*ohlcv.OHLCV, 0)
+		// The benchmark misses every fifth candle
+		for i, candle := range testCandleStream() {
+			if i%5 != 4 {
+				benchmark = append(benchmark, candle)
+			}
+		}
+
+		alignedAsset, alignedBenchmark := ohlcv.AlignByTimestamp(asset, benchmark)
+		assert.Equal(t, 32, len(alignedAsset))
+		assert.Equal(t, len(alignedAsset), len(alignedBenchmark))
+
+		batch := NewCorrelation(8)
+		for i := range alignedAsset {
+			batch.AddPairValue(alignedAsset[i].Close, alignedBenchmark[i].Close)
+		}
+
+		// Streaming alignment gives the same pairs
+		streaming := NewCorrelation(8)
+		aligner := ohlcv.NewAligner(func(a, b *ohlcv.OHLCV) {
+			assert.True(t, a.Timestamp.Equal(b.Timestamp))
+			streaming.AddPairValue(a.Close, b.Close)
+		})
+		assetStream := ohlcv.NewStream()
+		benchmarkStream := ohlcv.NewStream()
+		assetStream.OnUpdate(aligner.AddA)
+		benchmarkStream.OnUpdate(aligner.AddB)
+		j := 0
+		for _, candle := range asset {
+			assetStream.Add(candle)
+			// The benchmark lags one candle behind
+			for j < len(benchmark) && benchmark[j].Timestamp.Before(candle.Timestamp) {
+				benchmarkStream.Add(benchmark[j])
+				j++
+			}
+		}
+		for ; j < len(benchmark); j++ {
+			benchmarkStream.Add(benchmark[j])
+		}
+
+		assert.Equal(t, 25, len(batch.GetOutput()))
+		assert.Equal(t, batch.GetOutput(), streaming.GetOutput())
+		// The last asset candle waits for a benchmark candle that never comes
+		pendingA, pendingB := aligner.Pending()
+		assert.Equal(t, 1, pendingA)
+		assert.Equal(t, 0, pendingB)
+	})
+}
+
+func TestHistoricalVolatility(t *testing.T) {
+	expected := []struct {
+		estimator   VolatilityEstimator
+		length      int
+		first, last float64
+	}{
+		{VolatilityCloseToClose, 30, 0.205146, 0.229656},
+		{VolatilityParkinson, 31, 0.244641, 0.261259},
+		{VolatilityGarmanKlass, 31, 0.260367, 0.274537},
+		{VolatilityRogersSatchell, 31, 0.260685, 0.270372},
+		{VolatilityYangZhang, 30, 0.255923, 0.265329},
+	}
+
+	for _, e := range expected {
+		t.Run(e.estimator.String(), func(t *testing.T) {
+			hv := NewHistoricalVolatility(e.estimator, 10)
+			for _, candle := range testCandleStream() {
+				hv.AddCandle(candle)
+			}
+
+			output := hv.GetOutput()
+			assert.Equal(t, e.length, len(output))
+			assert.InDelta(t, e.first, output[0], 0.00001)
+			assert.InDelta(t, e.last, output[len(output)-1], 0.00001)
+			assert.Equal(t, e.estimator, hv.GetEstimator())
+
+			hv.Reset()
+			assert.Empty(t, hv.GetOutput())
+		})
+	}
+
+	t.Run("Annualisation", func(t *testing.T) {
+		assert.InDelta(t, 19656.0, PeriodsPerYear(5*time.Minute, 390*time.Minute, TradingDaysPerYear), 1e-9)
+		assert.InDelta(t, 8760.0, PeriodsPerYear(time.Hour, 24*time.Hour, 365), 1e-9)
+		assert.InDelta(t, 252.0, PeriodsPerYear(24*time.Hour, 390*time.Minute, TradingDaysPerYear), 1e-9)
+		assert.InDelta(t, 365.0/7, PeriodsPerYear(7*24*time.Hour, 390*time.Minute, TradingDaysPerYear), 1e-9)
+		assert.InDelta(t, 365.0, PeriodsPerYear(24*time.Hour, 24*time.Hour, 365), 1e-9)
+		assert.InDelta(t, 365.0/7, PeriodsPerYear(7*24*time.Hour, 24*time.Hour, 365), 1e-9)
+
+		// Multi-day bars never get more periods per year than shorter ones
+		tests := []struct {
+			days     int
+			equities float64
+			crypto   float64
+		}{
+			{1, 252, 365},
+			{2, 365.0 / 2, 365.0 / 2},
+			{3, 365.0 / 3, 365.0 / 3},
+			{4, 365.0 / 4, 365.0 / 4},
+			{5, 365.0 / 5, 365.0 / 5},
+			{6, 365.0 / 6, 365.0 / 6},
+			{7, 365.0 / 7, 365.0 / 7},
+		}
+		previous := math.Inf(1)
+		for _, tt := range tests {
+			bar := time.Duration(tt.days) * 24 * time.Hour
+			equities := PeriodsPerYear(bar, 390*time.Minute, TradingDaysPerYear)
+			assert.InDelta(t, tt.equities, equities, 1e-9, "%d day bars", tt.days)
+			assert.InDelta(t, tt.crypto, PeriodsPerYear(bar, 24*time.Hour, 365), 1e-9, "%d day bars", tt.days)
+			assert.Less(t, equities, previous, "%d day bars", tt.days)
+			previous = equities
+		}
+		assert.InDelta(t, 252.0, PeriodsPerYear(30*time.Hour, 390*time.Minute, TradingDaysPerYear), 1e-9)
+
+		daily := NewHistoricalVolatility(VolatilityParkinson, 10)
+		perBar := NewHistoricalVolatility(VolatilityParkinson, 10, WithVolatilityPeriodsPerYear(1))
+		for _, candle := range testCandleStream() {
+			daily.AddCandle(candle)
+			perBar.AddCandle(candle)
+		}
+		assert.InDelta(t, 0.261259/math.Sqrt(TradingDaysPerYear), perBar.GetOutput()[30], 0.00001)
+		assert.InDelta(t, daily.GetOutput()[30], perBar.GetOutput()[30]*math.Sqrt(TradingDaysPerYear), 1e-12)
+	})
+
+	t.Run("Close-to-close through AddValue", func(t *testing.T) {
+		hv := NewHistoricalVolatility(VolatilityCloseToClose, 10)
+		for _, candle := range testCandles {
+			hv.AddValue(candle[3])
+		}
+		assert.InDelta(t, 0.229656, hv.GetOutput()[29], 0.00001)
+	})
+}
+
+func TestPivots(t *testing.T) {
+	day1 := time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC)
+	day2 := day1.Add(24 * time.Hour)
+	candles := []*ohlcv.OHLCV{
+		ohlcv.NewOHLCV(day1, 100, 105, 98, 103, 1000),
+		ohlcv.NewOHLCV(day1.Add(time.Hour), 103, 106, 101, 104, 1000),
+		ohlcv.NewOHLCV(day2, 104, 105, 103, 104.5, 1000),
+		ohlcv.NewOHLCV(day2.Add(time.Hour), 104.5, 107, 104, 106, 1000),
+	}
+
+	expected := []struct {
+		pivotType  PivotType
+		pivot      float64
+		resistance []float64
+		support    []float64
+	}{
+		{PivotClassic, 102.666667, []float64{107.333333, 110.666667, 115.333333}, []float64{99.333333, 94.666667, 91.333333}},
+		{PivotFibonacci, 102.666667, []float64{105.722667, 107.610667, 110.666667}, []float64{99.610667, 97.722667, 94.666667}},
+		{PivotCamarilla, 102.666667, []float64{104.733333, 105.466667, 106.2, 108.4}, []float64{103.266667, 102.533333, 101.8, 99.6}},
+		{PivotWoodie, 103, []float64{108, 111, 116}, []float64{100, 95, 92}},
+		{PivotDeMark, 103.5, []float64{109}, []float64{101}},
+	}
+
+	for _, e := range expected {
+		t.Run(e.pivotType.String(), func(t *testing.T) {
+			pivots := NewPivots(e.pivotType)
+			published := make([]PivotLevels, 0)
+			pivots.OnLevels(func(levels PivotLevels) {
+				published = append(published, levels)
+			})
+
+			for i, candle := range candles {
+				pivots.AddCandle(candle)
+				_, ok := pivots.GetLevels()
+				assert.Equal(t, i >= 2, ok)
+			}
+
+			levels, _ := pivots.GetLevels()
+			assert.Equal(t, time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), levels.Period)
+			assert.InDelta(t, e.pivot, levels.Pivot, 0.00001)
+			assert.InDeltaSlice(t, e.resistance, levels.Resistance, 0.00001)
+			assert.InDeltaSlice(t, e.support, levels.Support, 0.00001)
+			assert.Equal(t, []PivotLevels{levels}, published)
+			assert.Equal(t, []PivotLevels{levels}, pivots.GetPivotOutput())
+			assert.InDeltaSlice(t, []float64{e.pivot, e.pivot}, pivots.GetOutput(), 0.00001)
+
+			pivots.Reset()
+			_, ok := pivots.GetLevels()
+			assert.False(t, ok)
+			assert.Empty(t, pivots.GetOutput())
+		})
+	}
+
+	t.Run("Weekly and monthly periods", func(t *testing.T) {
+		weekly := NewPivots(PivotClassic, WithPivotPeriod(SessionWeekly))
+		monthly := NewPivots(PivotClassic, WithPivotPeriod(SessionMonthly))
+		for _, ts := range []time.Time{
+			time.Date(2024, 1, 29, 10, 0, 0, 0, time.UTC), // Monday
+			time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC),
+			time.Date(2024, 2, 2, 10, 0, 0, 0, time.UTC), // Friday
+			time.Date(2024, 2, 5, 10, 0, 0, 0, time.UTC), // Monday
+		} {
+			candle := ohlcv.NewOHLCV(ts, 100, 101, 99, 100, 1000)
+			weekly.AddCandle(candle)
+			monthly.AddCandle(candle)
+		}
+
+		weeks := weekly.GetPivotOutput()
+		assert.Equal(t, 1, len(weeks))
+		assert.Equal(t, time.Date(2024, 2, 5, 0, 0, 0, 0, time.UTC), weeks[0].Period)
+		months := monthly.GetPivotOutput()
+		assert.Equal(t, 1, len(months))
+		assert.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), months[0].Period)
+	})
+
+	t.Run("Session start", func(t *testing.T) {
+		newYork, err := time.LoadLocation("America/New_York")
+		if err != nil {
+			t.Skip("time zone database not available")
+		}
+		open := 9*time.Hour + 30*time.Minute
+
+		// Before the open the candle belongs to the previous session
+		assert.Equal(t, time.Date(2024, 3, 8, 9, 30, 0, 0, newYork),
+			sessionStart(time.Date(2024, 3, 9, 9, 0, 0, 0, newYork), newYork, open, SessionDaily))
+		// The open keeps its wall-clock time across the daylight saving change
+		assert.Equal(t, time.Date(2024, 3, 10, 9, 30, 0, 0, newYork),
+			sessionStart(time.Date(2024, 3, 10, 12, 0, 0, 0, newYork), newYork, open, SessionDaily))
+		// Monday before the open still belongs to the previous week
+		assert.Equal(t, time.Date(2024, 2, 26, 9, 30, 0, 0, newYork),
+			sessionStart(time.Date(2024, 3, 4, 8, 0, 0, 0, newYork), newYork, open, SessionWeekly))
+		assert.Equal(t, time.Date(2024, 3, 1, 9, 30, 0, 0, newYork),
+			sessionStart(time.Date(2024, 3, 31, 20, 0, 0, 0, newYork), newYork, open, SessionMonthly))
+
+		pivots := NewPivots(PivotClassic, WithPivotSession(newYork, open))
+		pivots.AddCandle(ohlcv.NewOHLCV(time.Date(2024, 3, 8, 15, 0, 0, 0, newYork), 100, 101, 99, 100, 1000))
+		pivots.AddCandle(ohlcv.NewOHLCV(time.Date(2024, 3, 9, 9, 0, 0, 0, newYork), 100, 101, 99, 100, 1000))
+		_, ok := pivots.GetLevels()
+		assert.False(t, ok)
+	})
+
+	t.Run("Manual rollover", func(t *testing.T) {
+		pivots := NewPivots(PivotClassic)
+		for _, value := range []float64{100, 106, 98, 104} {
+			pivots.AddValue(value)
+		}
+		pivots.Rollover()
+		levels, ok := pivots.GetLevels()
+		assert.True(t, ok)
+		assert.InDelta(t, 102.666667, levels.Pivot, 0.00001)
+	})
+}
+
+func TestSwings(t *testing.T) {
+	t.Run("ZigZag", func(t *testing.T) {
+		zz := NewZigZag(5)
+		confirmed := make([]SwingPoint, 0)
+		zz.OnSwing(func(point SwingPoint) {
+			confirmed = append(confirmed, point)
+		})
+
+		bars := [][2]float64{{100, 99}, {102, 100}, {106, 103}, {104, 100.5}, {101, 98}, {100, 97}, {101.5, 99}, {102.5, 100}}
+		tentative := make([]int, 0)
+		for _, bar := range bars {
+			zz.AddOHLCValue(bar[0], bar[1], bar[1])
+			if points := zz.GetTentative(); len(points) > 0 {
+				tentative = append(tentative, points[0].Index)
+			}
+		}
+
+		expected := []SwingPoint{
+			{Kind: SwingLow, Price: 99, Index: 0, ConfirmedIndex: 2},
+			{Kind: SwingHigh, Price: 106, Index: 2, ConfirmedIndex: 3},
+			{Kind: SwingLow, Price: 97, Index: 5, ConfirmedIndex: 7},
+		}
+		assert.Equal(t, expected, zz.GetSwings())
+		assert.Equal(t, expected, confirmed)
+		assert.Equal(t, []float64{99, 106, 97}, zz.GetOutput())
+
+		// The tentative swing repaints as new extremes are made
+		assert.Equal(t, []int{2, 3, 4, 5, 5, 7}, tentative)
+		assert.Equal(t, []SwingPoint{{Kind: SwingHigh, Price: 102.5, Index: 7, ConfirmedIndex: -1}}, zz.GetTentative())
+
+		zz.Reset()
+		assert.Empty(t, zz.GetSwings())
+		assert.Empty(t, zz.GetTentative())
+	})
+
+	t.Run("ZigZag ATR", func(t *testing.T) {
+		zz := NewZigZagATR(5, 2)
+		candles := testCandleStream()
+		for _, candle := range candles {
+			zz.AddCandle(candle)
+		}
+
+		points := zz.GetSwings()
+		assert.Greater(t, len(points), 2)
+		for i, point := range points {
+			assert.Greater(t, point.ConfirmedIndex, point.Index)
+			assert.Equal(t, candles[point.Index].Timestamp, point.Timestamp)
+			if point.Kind == SwingHigh {
+				assert.Equal(t, candles[point.Index].High, point.Price)
+			} else {
+				assert.Equal(t, candles[point.Index].Low, point.Price)
+			}
+			if i > 0 {
+				assert.Equal(t, -points[i-1].Kind, point.Kind)
+			}
+		}
+		assert.Equal(t, 1, len(zz.GetTentative()))
+	})
+
+	t.Run("Fractals", func(t *testing.T) {
+		highs := []float64{10, 11, 13, 12, 11, 12, 14, 13, 12}
+		lows := []float64{9, 8, 10, 11, 10, 9, 11, 12, 11}
+		start := time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC)
+		at := func(i int) time.Time {
+			return start.Add(time.Duration(i) * time.Minute)
+		}
+
+		fractals := NewFractals()
+		var tentative []SwingPoint
+		for i := range highs {
+			fractals.AddCandle(ohlcv.NewOHLCV(at(i), lows[i], highs[i], lows[i], highs[i], 1000))
+			if i == 7 {
+				tentative = fractals.GetTentative()
+			}
+		}
+
+		assert.Equal(t, []SwingPoint{
+			{Kind: SwingHigh, Price: 13, Index: 2, Timestamp: at(2), ConfirmedIndex: 4},
+			{Kind: SwingLow, Price: 9, Index: 5, Timestamp: at(5), ConfirmedIndex: 7},
+			{Kind: SwingHigh, Price: 14, Index: 6, Timestamp: at(6), ConfirmedIndex: 8},
+		}, fractals.GetSwings())
+		assert.Equal(t, []float64{13, 9, 14}, fractals.GetOutput())
+		assert.Equal(t, []SwingPoint{{Kind: SwingHigh, Price: 14, Index: 6, Timestamp: at(6), ConfirmedIndex: -1}}, tentative)
+		assert.Equal(t, 5, fractals.GetWindowSize())
+	})
+
+	t.Run("PivotHighLow", func(t *testing.T) {
+		// With one bar on the left and three on the right
+		phl := NewPivotHighLow(1, 3)
+		for _, value := range []float64{5, 7, 6, 6.5, 4, 3, 5, 6, 7} {
+			phl.AddValue(value)
+		}
+
+		swings := phl.GetSwings()
+		assert.Equal(t, 3, len(swings))
+		assert.Equal(t, SwingPoint{Kind: SwingHigh, Price: 7, Index: 1, ConfirmedIndex: 4}, swings[0])
+		assert.Equal(t, SwingPoint{Kind: SwingHigh, Price: 6.5, Index: 3, ConfirmedIndex: 6}, swings[1])
+		assert.Equal(t, SwingPoint{Kind: SwingLow, Price: 3, Index: 5, ConfirmedIndex: 8}, swings[2])
+
+		// The last bar is above the bar before it but has no bars after it yet
+		assert.Equal(t, []SwingPoint{{Kind: SwingHigh, Price: 7, Index: 8, ConfirmedIndex: -1}}, phl.GetTentative())
+
+		phl.Reset()
+		assert.Empty(t, phl.GetSwings())
+		assert.Empty(t, phl.GetOutput())
+	})
+}
+
+func TestDivergenceDetector(t *testing.T) {
+	// Lows at 1, 3, 6, 8, 11 and highs at 5, 7, 10, 12 with one-bar pivots
+	prices := []float64{10, 8, 9, 7, 9, 11, 10, 12, 9, 10, 11, 9.5, 10.8, 10.5}
+	values := []float64{50, 30, 40, 35, 50, 70, 60, 65, 45, 50, 55, 40, 60, 60}
+
+	t.Run("Kinds", func(t *testing.T) {
+		dd := NewDivergenceDetector(1, 1)
+		events := make([]Divergence, 0)
+		dd.OnDivergence(func(divergence Divergence) {
+			events = append(events, divergence)
+		})
+		for i := range prices {
+			dd.AddPairValue(prices[i], values[i])
+		}
+
+		divergences := dd.GetDivergences()
+		assert.Equal(t, divergences, events)
+		if assert.Len(t, divergences, 4) {
+			expected := []struct {
+				kind       DivergenceKind
+				start, end int
+				confirmed  int
+			}{
+				{RegularBullish, 1, 3, 4},
+				{RegularBearish, 5, 7, 8},
+				{HiddenBullish, 8, 11, 12},
+				{HiddenBearish, 10, 12, 13},
+			}
+			for i, e := range expected {
+				assert.Equal(t, e.kind, divergences[i].Kind)
+				assert.Equal(t, e.start, divergences[i].Start.Index)
+				assert.Equal(t, e.end, divergences[i].End.Index)
+				assert.Equal(t, e.confirmed, divergences[i].End.ConfirmedIndex)
+				assert.Equal(t, values[e.start], divergences[i].StartValue)
+				assert.Equal(t, values[e.end], divergences[i].EndValue)
+				assert.Equal(t, prices[e.end], divergences[i].End.Price)
+			}
+			assert.True(t, divergences[0].Kind.IsBullish())
+			assert.False(t, divergences[1].Kind.IsBullish())
+			assert.Equal(t, "HiddenBearish", divergences[3].Kind.String())
+		}
+
+		dd.Reset()
+		assert.Empty(t, dd.GetDivergences())
+	})
+
+	t.Run("Max lookback", func(t *testing.T) {
+		dd := NewDivergenceDetector(1, 1, WithDivergenceMaxLookback(1))
+		for i := range prices {
+			dd.AddPairValue(prices[i], values[i])
+		}
+		assert.Empty(t, dd.GetDivergences())
+	})
+
+	t.Run("Warm-up", func(t *testing.T) {
+		// Swings without an indicator value are skipped
+		dd := NewDivergenceDetector(1, 1)
+		for i := range prices {
+			value := values[i]
+			if i < 2 {
+				value = math.NaN()
+			}
+			dd.AddPairValue(prices[i], value)
+		}
+		divergences := dd.GetDivergences()
+		if assert.Len(t, divergences, 3) {
+			assert.Equal(t, RegularBearish, divergences[0].Kind)
+		}
+	})
+
+	t.Run("RSI", func(t *testing.T) {
+		dd := NewDivergenceDetector(2, 2)
+		rsi := NewRSI(5)
+		for _, candle := range testCandleStream() {
+			rsi.AddValue(candle.Close)
+			value := math.NaN()
+			if rsi.IsInitialized() {
+				value, _ = rsi.GetLastValue()
+			}
+			dd.AddCandleValue(candle, value)
+		}
+
+		for _, d := range dd.GetDivergences() {
+			assert.Equal(t, d.Start.Kind, d.End.Kind)
+			assert.Less(t, d.Start.Index, d.End.Index)
+			priceUp := d.End.Price > d.Start.Price
+			valueUp := d.EndValue > d.StartValue
+			assert.NotEqual(t, priceUp, valueUp)
+			assert.Equal(t, d.End.Kind == SwingLow, d.Kind.IsBullish())
+			assert.False(t, d.End.Timestamp.IsZero())
+		}
+	})
+}
+
+func TestNonFiniteRecovery(t *testing.T) {
+	// A NaN or infinite value gives NaN outputs while it is in the window,
+	// then the outputs match those of a series without it
+	const bad = 10
+	series := make([]float64, 60)
+	for i := range series {
+		series[i] = 100 + 5*math.Sin(float64(i)*1.3) + 3*math.Cos(float64(i)*0.7)
+	}
+
+	for _, value := range []float64{math.NaN(), math.Inf(-1)} {
+		tests := []struct {
+			name             string
+			indicator, clean Indicator
+		}{
+			{"SMA", NewSMA(5), NewSMA(5)},
+			{"WMA", NewWMA(5), NewWMA(5)},
+			{"StdDev", NewStdDev(5), NewStdDev(5)},
+			{"Variance", NewVariance(5, WithVarianceSample()), NewVariance(5, WithVarianceSample())},
+			{"ZScore", NewZScore(5), NewZScore(5)},
+			{"LinReg", NewLinReg(5), NewLinReg(5)},
+			{"BBands", NewBBands(5, 2), NewBBands(5, 2)},
+		}
+		for _, tt := range tests {
+			for i, v := range series {
+				if i == bad {
+					tt.indicator.AddValue(value)
+				} else {
+					tt.indicator.AddValue(v)
+				}
+				tt.clean.AddValue(v)
+			}
+
+			output, expected := tt.indicator.GetOutput(), tt.clean.GetOutput()
+			if !assert.Equal(t, len(expected), len(output), tt.name) {
+				continue
+			}
+			for i := range output {
+				bar := i + len(series) - len(output)
+				if bar >= bad && bar < bad+5 {
+					assert.True(t, math.IsNaN(output[i]), "%s bar %d", tt.name, bar)
+				} else {
+					assert.InDelta(t, expected[i], output[i], 1e-9, "%s bar %d", tt.name, bar)
+				}
+			}
+		}
+
+		correlation, clean := NewCorrelation(5), NewCorrelation(5)
+		for i, v := range series {
+			other := series[len(series)-1-i]
+			if i == bad {
+				correlation.AddPairValue(value, other)
+			} else {
+				correlation.AddPairValue(v, other)
+			}
+			clean.AddPairValue(v, other)
+		}
+		output, expected := correlation.GetOutput(), clean.GetOutput()
+		assert.True(t, math.IsNaN(output[bad-4]))
+		assert.InDelta(t, expected[bad+1], output[bad+1], 1e-9)
+		assert.InDelta(t, expected[len(expected)-1], output[len(output)-1], 1e-9)
+	}
+}
@@ -3,6 +3,8 @@ package indicators
 
 import (
 	"fmt"
+
+	"github.com/revanthstrakz/gotalipp/talipp/ohlcv"
 )
 
 // Indicator is the interface that all indicators must implement
@@ -24,6 +26,38 @@ type IndicatorWithWindow interface {
 	GetWindowSize() int
 }
 
+// ValueIndicator is an indicator producing a single value per step that
+// exposes its latest value, so that it can be chained onto other indicators
+type ValueIndicator interface {
+	Indicator
+	// IsInitialized returns whether the indicator has produced a value
+	IsInitialized() bool
+	// GetLastValue returns the latest output value
+	GetLastValue() (float64, error)
+}
+
+// CandleIndicator is an indicator that consumes full OHLCV candles
+type CandleIndicator interface {
+	Indicator
+	// AddCandle adds a new candle to the indicator
+	AddCandle(*ohlcv.OHLCV)
+}
+
+// PairIndicator is an indicator computed from two series that advance
+// together, such as an asset and its benchmark. Both values of a pair must
+// belong to the same timestamp; see ohlcv.AlignByTimestamp and ohlcv.Aligner
+// for lining up two streams.
+type PairIndicator interface {
+	// AddPairValue adds the next value of the first and second series
+	AddPairValue(first, second float64)
+	// GetOutput returns the current output values of the indicator
+	GetOutput() []float64
+	// GetName returns the name of the indicator
+	GetName() string
+	// Reset clears all values in the indicator
+	Reset()
+}
+
 // BaseIndicator provides common functionality for all indicators
 type BaseIndicator struct {
 	name        string
@@ -73,6 +107,11 @@ func (bi *BaseIndicator) AddOutput(value float64) {
 	bi.initialized = true
 }
 
+// outputLen returns the number of output values
+func (bi *BaseIndicator) outputLen() int {
+	return len(bi.output)
+}
+
 // GetValue returns the value at the specified index
 func (bi *BaseIndicator) GetValue(index int) (float64, error) {
 	if index < 0 || index >= len(bi.output) {
@@ -88,3 +127,35 @@ func (bi *BaseIndicator) GetLastValue() (float64, error) {
 	}
 	return bi.output[len(bi.output)-1], nil
 }
+
+// TrendDirection is the direction of the trend tracked by trailing-stop indicators
+type TrendDirection int
+
+const (
+	// TrendDown means price is below the trailing stop (short side)
+	TrendDown TrendDirection = -1
+	// TrendUp means price is above the trailing stop (long side)
+	TrendUp TrendDirection = 1
+)
+
+// String returns the name of the trend direction
+func (d TrendDirection) String() string {
+	switch d {
+	case TrendUp:
+		return "up"
+	case TrendDown:
+		return "down"
+	default:
+		return fmt.Sprintf("TrendDirection(%d)", int(d))
+	}
+}
+
+// TrailingStopOutput represents the per-bar output of trailing-stop indicators
+type TrailingStopOutput struct {
+	// Stop is the trailing stop level for the bar
+	Stop float64
+	// Trend is the trend direction after the bar
+	Trend TrendDirection
+	// Flipped is true when the trend reversed on the bar
+	Flipped bool
+}
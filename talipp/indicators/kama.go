@@ -0,0 +1,79 @@
+package indicators
+
+import (
+	"math"
+)
+
+// KAMA represents Kaufman's Adaptive Moving Average indicator
+type KAMA struct {
+	*BaseIndicator
+	windowSize int
+	fastSC     float64
+	slowSC     float64
+	values     []float64
+	lastValue  float64
+}
+
+// NewKAMA creates a new Kaufman's Adaptive Moving Average indicator
+// windowSize: the period for the efficiency ratio (default 10)
+// fastPeriod: the period of the fastest smoothing constant (default 2)
+// slowPeriod: the period of the slowest smoothing constant (default 30)
+func NewKAMA(windowSize, fastPeriod, slowPeriod int) *KAMA {
+	if windowSize <= 0 || fastPeriod <= 0 || slowPeriod <= 0 {
+		panic("All periods must be greater than 0")
+	}
+
+	return &KAMA{
+		BaseIndicator: NewBaseIndicator("KAMA"),
+		windowSize:    windowSize,
+		fastSC:        2.0 / float64(fastPeriod+1),
+		slowSC:        2.0 / float64(slowPeriod+1),
+		values:        make([]float64, 0),
+	}
+}
+
+// AddValue adds a new value to the KAMA calculation
+func (kama *KAMA) AddValue(value float64) {
+	kama.values = append(kama.values, value)
+
+	// The efficiency ratio needs windowSize changes
+	if len(kama.values) > kama.windowSize+1 {
+		kama.values = kama.values[1:]
+	}
+	if len(kama.values) <= kama.windowSize {
+		return
+	}
+
+	// The first KAMA value starts from the previous input
+	if !kama.IsInitialized() {
+		kama.lastValue = kama.values[kama.windowSize-1]
+	}
+
+	// Efficiency ratio = net change / sum of absolute changes
+	change := math.Abs(value - kama.values[0])
+	var volatility float64
+	for i := 1; i < len(kama.values); i++ {
+		volatility += math.Abs(kama.values[i] - kama.values[i-1])
+	}
+	var er float64
+	if volatility != 0 {
+		er = change / volatility
+	}
+
+	// Smoothing constant = (ER * (fastSC - slowSC) + slowSC)^2
+	sc := math.Pow(er*(kama.fastSC-kama.slowSC)+kama.slowSC, 2)
+	kama.lastValue += sc * (value - kama.lastValue)
+	kama.AddOutput(kama.lastValue)
+}
+
+// GetWindowSize returns the window size of the KAMA
+func (kama *KAMA) GetWindowSize() int {
+	return kama.windowSize
+}
+
+// Reset clears all values in the KAMA
+func (kama *KAMA) Reset() {
+	kama.BaseIndicator.Reset()
+	kama.values = make([]float64, 0)
+	kama.lastValue = 0
+}
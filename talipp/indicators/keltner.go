@@ -0,0 +1,81 @@
+package indicators
+
+// Keltner represents the Keltner Channels indicator: a moving average of the
+// close with bands at a multiple of the ATR
+type Keltner struct {
+	*BaseIndicator
+	channel
+	windowSize int
+	multiplier float64
+	maType     MAType
+	ma         MovingAverage
+	atr        *ATR
+}
+
+// KeltnerOption configures optional Keltner Channels parameters
+type KeltnerOption func(*Keltner)
+
+// WithKeltnerMAType sets the moving average used for the middle line (default MATypeEMA)
+func WithKeltnerMAType(maType MAType) KeltnerOption {
+	return func(kc *Keltner) {
+		kc.maType = maType
+	}
+}
+
+// NewKeltner creates a new Keltner Channels indicator
+// windowSize: the period for the middle line moving average (default 20)
+// atrWindowSize: the period for the ATR (default 10)
+// multiplier: the ATR multiple for the bands (default 2.0)
+func NewKeltner(windowSize, atrWindowSize int, multiplier float64, opts ...KeltnerOption) *Keltner {
+	if windowSize <= 0 || atrWindowSize <= 0 {
+		panic("All periods must be greater than 0")
+	}
+
+	kc := &Keltner{
+		BaseIndicator: NewBaseIndicator("Keltner"),
+		channel:       newChannel(),
+		windowSize:    windowSize,
+		multiplier:    multiplier,
+		maType:        MATypeEMA,
+		atr:           NewATR(atrWindowSize),
+	}
+	for _, opt := range opts {
+		opt(kc)
+	}
+	kc.ma = NewMovingAverage(kc.maType, windowSize)
+
+	return kc
+}
+
+// AddOHLCValue adds a new OHLC candle data to the Keltner Channels calculation
+func (kc *Keltner) AddOHLCValue(high, low, close float64) {
+	kc.ma.AddValue(close)
+	kc.atr.AddOHLCValue(high, low, close)
+
+	if kc.ma.IsInitialized() && kc.atr.IsInitialized() {
+		middle, _ := kc.ma.GetLastValue()
+		atrValue, _ := kc.atr.GetLastValue()
+
+		kc.addBands(middle+kc.multiplier*atrValue, middle, middle-kc.multiplier*atrValue)
+		kc.AddOutput(middle)
+	}
+}
+
+// AddValue is not the preferred method for Keltner, but can be used for compatibility
+// with the Indicator interface. It will use the value as high, low and close.
+func (kc *Keltner) AddValue(value float64) {
+	kc.AddOHLCValue(value, value, value)
+}
+
+// GetWindowSize returns the window size of the middle line
+func (kc *Keltner) GetWindowSize() int {
+	return kc.windowSize
+}
+
+// Reset clears all values in the Keltner Channels
+func (kc *Keltner) Reset() {
+	kc.BaseIndicator.Reset()
+	kc.resetBands()
+	kc.ma.Reset()
+	kc.atr.Reset()
+}
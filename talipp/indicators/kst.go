@@ -0,0 +1,104 @@
+package indicators
+
+// KST represents the Know Sure Thing indicator, a weighted sum of four
+// smoothed rates of change with a signal line
+type KST struct {
+	*BaseIndicator
+	components []*Chain
+	signal     *SMA
+	kstValues  []float64
+	signalLine []float64
+}
+
+// KSTOutput represents the output of KST calculations
+type KSTOutput struct {
+	KST    float64
+	Signal float64
+}
+
+// NewKST creates a new Know Sure Thing indicator
+// rocLengths: the periods of the four rates of change (default 10, 15, 20, 30)
+// smaLengths: the periods of the four SMAs smoothing them (default 10, 10, 10, 15)
+// signalLength: the period of the signal line SMA (default 9)
+func NewKST(rocLengths, smaLengths [4]int, signalLength int) *KST {
+	if signalLength <= 0 {
+		panic("All periods must be greater than 0")
+	}
+
+	components := make([]*Chain, 4)
+	for i := range components {
+		if rocLengths[i] <= 0 || smaLengths[i] <= 0 {
+			panic("All periods must be greater than 0")
+		}
+		components[i] = NewChain(NewROC(rocLengths[i]), NewSMA(smaLengths[i]))
+	}
+
+	return &KST{
+		BaseIndicator: NewBaseIndicator("KST"),
+		components:    components,
+		signal:        NewSMA(signalLength),
+		kstValues:     make([]float64, 0),
+		signalLine:    make([]float64, 0),
+	}
+}
+
+// AddValue adds a new value to the KST calculation
+func (kst *KST) AddValue(value float64) {
+	ready := true
+	for _, component := range kst.components {
+		component.AddValue(value)
+		ready = ready && component.IsInitialized()
+	}
+	if !ready {
+		return
+	}
+
+	// KST = RCMA1 + 2 * RCMA2 + 3 * RCMA3 + 4 * RCMA4
+	var kstValue float64
+	for i, component := range kst.components {
+		componentValue, _ := component.GetLastValue()
+		kstValue += float64(i+1) * componentValue
+	}
+	kst.kstValues = append(kst.kstValues, kstValue)
+	kst.AddOutput(kstValue)
+
+	kst.signal.AddValue(kstValue)
+	if kst.signal.IsInitialized() {
+		signalValue, _ := kst.signal.GetLastValue()
+		kst.signalLine = append(kst.signalLine, signalValue)
+	}
+}
+
+// Reset clears all values in the KST
+func (kst *KST) Reset() {
+	kst.BaseIndicator.Reset()
+	for _, component := range kst.components {
+		component.Reset()
+	}
+	kst.signal.Reset()
+	kst.kstValues = make([]float64, 0)
+	kst.signalLine = make([]float64, 0)
+}
+
+// GetKSTOutput returns the complete KST output (KST, Signal) for every value
+// that has a signal line
+func (kst *KST) GetKSTOutput() []KSTOutput {
+	kstStart := len(kst.kstValues) - len(kst.signalLine)
+
+	results := make([]KSTOutput, len(kst.signalLine))
+	for i := range kst.signalLine {
+		results[i] = KSTOutput{
+			KST:    kst.kstValues[kstStart+i],
+			Signal: kst.signalLine[i],
+		}
+	}
+
+	return results
+}
+
+// GetSignalLine returns just the signal line values
+func (kst *KST) GetSignalLine() []float64 {
+	result := make([]float64, len(kst.signalLine))
+	copy(result, kst.signalLine)
+	return result
+}
@@ -0,0 +1,279 @@
+package indicators
+
+import (
+	"math"
+)
+
+// rollingRegression fits a least squares line through the values of a sliding
+// window, the oldest value being at x = 0 and the latest at x = windowSize-1.
+//
+// The sums are updated in O(1) per value. The sum of x*y is recomputed from the
+// window contents periodically, like rollingStats does, to keep rounding errors
+// bounded.
+type rollingRegression struct {
+	windowSize int
+	stats      *rollingStats
+	sumXY      float64
+	sxx        float64
+	evictions  int
+}
+
+// newRollingRegression creates a new rollingRegression for the specified window size
+func newRollingRegression(windowSize int) *rollingRegression {
+	if windowSize <= 1 {
+		panic("Window size must be greater than 1")
+	}
+
+	n := float64(windowSize)
+	return &rollingRegression{
+		windowSize: windowSize,
+		stats:      newRollingStats(windowSize),
+		sxx:        n * (n*n - 1) / 12.0,
+	}
+}
+
+// Add pushes a new value into the window
+func (rr *rollingRegression) Add(value float64) {
+	if !rr.stats.Full() {
+		rr.sumXY += float64(rr.stats.Len()) * value
+		rr.stats.Add(value)
+		return
+	}
+
+	// Every remaining value moves one step to the left and the new value
+	// takes the last position
+	previousSum := rr.stats.Sum()
+	removed, _ := rr.stats.Add(value)
+	rr.sumXY += removed - previousSum + float64(rr.windowSize-1)*value
+
+	rr.evictions++
+	// A NaN or infinite value leaves sumXY non-finite until it is recomputed
+	if !isFinite(rr.sumXY) || rr.evictions >= rr.windowSize && rr.evictions >= rollingResyncInterval {
+		rr.sumXY = 0
+		for i := 0; i < rr.windowSize; i++ {
+			rr.sumXY += float64(i) * rr.stats.At(i)
+		}
+		rr.evictions = 0
+	}
+}
+
+// Full returns whether the window holds windowSize values
+func (rr *rollingRegression) Full() bool {
+	return rr.stats.Full()
+}
+
+// sxy returns the sum of the products of the x and y deviations from their means
+func (rr *rollingRegression) sxy() float64 {
+	meanX := float64(rr.windowSize-1) / 2.0
+	return rr.sumXY - meanX*rr.stats.Sum()
+}
+
+// Slope returns the slope of the regression line per value
+func (rr *rollingRegression) Slope() float64 {
+	return rr.sxy() / rr.sxx
+}
+
+// Intercept returns the value of the regression line at the oldest value of the window
+func (rr *rollingRegression) Intercept() float64 {
+	meanX := float64(rr.windowSize-1) / 2.0
+	return rr.stats.Mean() - rr.Slope()*meanX
+}
+
+// Value returns the value of the regression line at the latest value of the window
+func (rr *rollingRegression) Value() float64 {
+	return rr.Intercept() + rr.Slope()*float64(rr.windowSize-1)
+}
+
+// RSquared returns the coefficient of determination of the fit, 0 when all
+// values of the window are equal
+func (rr *rollingRegression) RSquared() float64 {
+	syy := rr.stats.Variance() * float64(rr.windowSize)
+	if syy == 0 {
+		return 0
+	}
+
+	sxy := rr.sxy()
+	return math.Min(sxy*sxy/(rr.sxx*syy), 1)
+}
+
+// StdErr returns the standard error of the estimate, sqrt(SSE / (n-2))
+func (rr *rollingRegression) StdErr() float64 {
+	if rr.windowSize <= 2 {
+		return 0
+	}
+
+	sxy := rr.sxy()
+	sse := rr.stats.Variance()*float64(rr.windowSize) - sxy*sxy/rr.sxx
+	if sse < 0 {
+		sse = 0
+	}
+	return math.Sqrt(sse / float64(rr.windowSize-2))
+}
+
+// Reset clears the window
+func (rr *rollingRegression) Reset() {
+	rr.stats.Reset()
+	rr.sumXY = 0
+	rr.evictions = 0
+}
+
+// linRegField selects the base output of a LinReg indicator
+type linRegField int
+
+const (
+	linRegValue linRegField = iota
+	linRegSlope
+	linRegIntercept
+	linRegRSquared
+	linRegStdErr
+)
+
+// LinReg represents a rolling linear regression indicator. Every output
+// describes the least squares line fitted through the last windowSize values.
+type LinReg struct {
+	*BaseIndicator
+	windowSize int
+	field      linRegField
+	regression *rollingRegression
+	results    []LinRegOutput
+}
+
+// LinRegOutput represents the output of linear regression calculations
+type LinRegOutput struct {
+	// Value is the regression line at the latest value
+	Value float64
+	// Slope is the change of the regression line per value
+	Slope float64
+	// Intercept is the regression line at the oldest value of the window
+	Intercept float64
+	// RSquared is the coefficient of determination, from 0 to 1
+	RSquared float64
+	// StdErr is the standard error of the estimate
+	StdErr float64
+}
+
+// newLinReg creates a new LinReg with the specified base output
+func newLinReg(name string, windowSize int, field linRegField) *LinReg {
+	return &LinReg{
+		BaseIndicator: NewBaseIndicator(name),
+		windowSize:    windowSize,
+		field:         field,
+		regression:    newRollingRegression(windowSize),
+		results:       make([]LinRegOutput, 0),
+	}
+}
+
+// NewLinReg creates a new linear regression indicator with specified window size (default 14).
+// The base output is the value of the regression line at the latest value.
+func NewLinReg(windowSize int) *LinReg {
+	return newLinReg("LinReg", windowSize, linRegValue)
+}
+
+// NewLinRegSlope creates a new linear regression indicator with specified window size (default 14).
+// The base output is the slope of the regression line.
+func NewLinRegSlope(windowSize int) *LinReg {
+	return newLinReg("LinRegSlope", windowSize, linRegSlope)
+}
+
+// NewLinRegIntercept creates a new linear regression indicator with specified window size (default 14).
+// The base output is the intercept of the regression line at the oldest value of the window.
+func NewLinRegIntercept(windowSize int) *LinReg {
+	return newLinReg("LinRegIntercept", windowSize, linRegIntercept)
+}
+
+// NewLinRegRSquared creates a new linear regression indicator with specified window size (default 14).
+// The base output is the coefficient of determination (R-squared).
+func NewLinRegRSquared(windowSize int) *LinReg {
+	return newLinReg("LinRegRSquared", windowSize, linRegRSquared)
+}
+
+// NewLinRegStdErr creates a new linear regression indicator with specified window size (default 14).
+// The base output is the standard error of the estimate.
+func NewLinRegStdErr(windowSize int) *LinReg {
+	return newLinReg("LinRegStdErr", windowSize, linRegStdErr)
+}
+
+// AddValue adds a new value to the linear regression calculation
+func (lr *LinReg) AddValue(value float64) {
+	lr.regression.Add(value)
+	if !lr.regression.Full() {
+		return
+	}
+
+	result := LinRegOutput{
+		Value:     lr.regression.Value(),
+		Slope:     lr.regression.Slope(),
+		Intercept: lr.regression.Intercept(),
+		RSquared:  lr.regression.RSquared(),
+		StdErr:    lr.regression.StdErr(),
+	}
+	lr.results = append(lr.results, result)
+
+	switch lr.field {
+	case linRegSlope:
+		lr.AddOutput(result.Slope)
+	case linRegIntercept:
+		lr.AddOutput(result.Intercept)
+	case linRegRSquared:
+		lr.AddOutput(result.RSquared)
+	case linRegStdErr:
+		lr.AddOutput(result.StdErr)
+	default:
+		lr.AddOutput(result.Value)
+	}
+}
+
+// GetWindowSize returns the window size of the LinReg
+func (lr *LinReg) GetWindowSize() int {
+	return lr.windowSize
+}
+
+// Reset clears all values in the LinReg
+func (lr *LinReg) Reset() {
+	lr.BaseIndicator.Reset()
+	lr.regression.Reset()
+	lr.results = make([]LinRegOutput, 0)
+}
+
+// GetLinRegOutput returns the complete linear regression output
+func (lr *LinReg) GetLinRegOutput() []LinRegOutput {
+	results := make([]LinRegOutput, len(lr.results))
+	copy(results, lr.results)
+	return results
+}
+
+// GetSlope returns just the slope values
+func (lr *LinReg) GetSlope() []float64 {
+	result := make([]float64, len(lr.results))
+	for i, r := range lr.results {
+		result[i] = r.Slope
+	}
+	return result
+}
+
+// GetIntercept returns just the intercept values
+func (lr *LinReg) GetIntercept() []float64 {
+	result := make([]float64, len(lr.results))
+	for i, r := range lr.results {
+		result[i] = r.Intercept
+	}
+	return result
+}
+
+// GetRSquared returns just the R-squared values
+func (lr *LinReg) GetRSquared() []float64 {
+	result := make([]float64, len(lr.results))
+	for i, r := range lr.results {
+		result[i] = r.RSquared
+	}
+	return result
+}
+
+// GetStdErr returns just the standard error values
+func (lr *LinReg) GetStdErr() []float64 {
+	result := make([]float64, len(lr.results))
+	for i, r := range lr.results {
+		result[i] = r.StdErr
+	}
+	return result
+}
@@ -0,0 +1,50 @@
+package indicators
+
+// LinRegChannel represents a linear regression channel: the regression line at
+// the latest value with bands a multiple of the standard error of the
+// estimate above and below it
+type LinRegChannel struct {
+	*BaseIndicator
+	channel
+	windowSize int
+	multiplier float64
+	regression *rollingRegression
+}
+
+// NewLinRegChannel creates a new linear regression channel indicator
+// windowSize: the period for the regression (default 100)
+// multiplier: the standard error multiple for the bands (default 2.0)
+func NewLinRegChannel(windowSize int, multiplier float64) *LinRegChannel {
+	return &LinRegChannel{
+		BaseIndicator: NewBaseIndicator("LinRegChannel"),
+		channel:       newChannel(),
+		windowSize:    windowSize,
+		multiplier:    multiplier,
+		regression:    newRollingRegression(windowSize),
+	}
+}
+
+// AddValue adds a new value to the linear regression channel calculation
+func (lrc *LinRegChannel) AddValue(value float64) {
+	lrc.regression.Add(value)
+
+	if lrc.regression.Full() {
+		middle := lrc.regression.Value()
+		offset := lrc.multiplier * lrc.regression.StdErr()
+
+		lrc.addBands(middle+offset, middle, middle-offset)
+		lrc.AddOutput(middle)
+	}
+}
+
+// GetWindowSize returns the window size of the LinRegChannel
+func (lrc *LinRegChannel) GetWindowSize() int {
+	return lrc.windowSize
+}
+
+// Reset clears all values in the LinRegChannel
+func (lrc *LinRegChannel) Reset() {
+	lrc.BaseIndicator.Reset()
+	lrc.resetBands()
+	lrc.regression.Reset()
+}
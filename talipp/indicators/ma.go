@@ -0,0 +1,114 @@
+package indicators
+
+import (
+	"fmt"
+)
+
+// MAType identifies a moving average implementation
+type MAType int
+
+const (
+	// MATypeSMA selects the Simple Moving Average
+	MATypeSMA MAType = iota
+	// MATypeEMA selects the Exponential Moving Average
+	MATypeEMA
+	// MATypeRMA selects Wilder's smoothing (running moving average, SMMA)
+	MATypeRMA
+	// MATypeWMA selects the Weighted Moving Average
+	MATypeWMA
+	// MATypeDEMA selects the Double Exponential Moving Average
+	MATypeDEMA
+	// MATypeTEMA selects the Triple Exponential Moving Average
+	MATypeTEMA
+	// MATypeHMA selects the Hull Moving Average
+	MATypeHMA
+	// MATypeZLEMA selects the Zero Lag Exponential Moving Average
+	MATypeZLEMA
+	// MATypeKAMA selects Kaufman's Adaptive Moving Average with fast period 2 and slow period 30
+	MATypeKAMA
+	// MATypeALMA selects the Arnaud Legoux Moving Average with offset 0.85 and sigma 6
+	MATypeALMA
+	// MATypeT3 selects Tillson's T3 Moving Average with volume factor 0.7
+	MATypeT3
+	// MATypeVWMA selects the Volume Weighted Moving Average. Values added
+	// through AddValue have a volume of 1.
+	MATypeVWMA
+	// MATypeMcGinley selects the McGinley Dynamic
+	MATypeMcGinley
+)
+
+// String returns the name of the moving average type
+func (t MAType) String() string {
+	switch t {
+	case MATypeSMA:
+		return "SMA"
+	case MATypeEMA:
+		return "EMA"
+	case MATypeRMA:
+		return "RMA"
+	case MATypeWMA:
+		return "WMA"
+	case MATypeDEMA:
+		return "DEMA"
+	case MATypeTEMA:
+		return "TEMA"
+	case MATypeHMA:
+		return "HMA"
+	case MATypeZLEMA:
+		return "ZLEMA"
+	case MATypeKAMA:
+		return "KAMA"
+	case MATypeALMA:
+		return "ALMA"
+	case MATypeT3:
+		return "T3"
+	case MATypeVWMA:
+		return "VWMA"
+	case MATypeMcGinley:
+		return "McGinley"
+	default:
+		return fmt.Sprintf("MAType(%d)", int(t))
+	}
+}
+
+// MovingAverage is the interface implemented by all moving averages so that
+// composite indicators can be configured with any of them
+type MovingAverage interface {
+	ValueIndicator
+	// GetWindowSize returns the window size of the moving average
+	GetWindowSize() int
+}
+
+// NewMovingAverage creates a new moving average of the given type and window size
+func NewMovingAverage(maType MAType, windowSize int) MovingAverage {
+	switch maType {
+	case MATypeSMA:
+		return NewSMA(windowSize)
+	case MATypeEMA:
+		return NewEMA(windowSize)
+	case MATypeRMA:
+		return NewRMA(windowSize)
+	case MATypeWMA:
+		return NewWMA(windowSize)
+	case MATypeDEMA:
+		return NewDEMA(windowSize)
+	case MATypeTEMA:
+		return NewTEMA(windowSize)
+	case MATypeHMA:
+		return NewHMA(windowSize)
+	case MATypeZLEMA:
+		return NewZLEMA(windowSize)
+	case MATypeKAMA:
+		return NewKAMA(windowSize, 2, 30)
+	case MATypeALMA:
+		return NewALMA(windowSize, 0.85, 6.0)
+	case MATypeT3:
+		return NewT3(windowSize, 0.7)
+	case MATypeVWMA:
+		return NewVWMA(windowSize)
+	case MATypeMcGinley:
+		return NewMcGinley(windowSize)
+	default:
+		panic(fmt.Sprintf("Unknown moving average type: %s", maType))
+	}
+}
@@ -3,12 +3,14 @@ package indicators
 // MACD represents Moving Average Convergence Divergence indicator
 type MACD struct {
 	*BaseIndicator
-	fastEMA    *EMA
-	slowEMA    *EMA
-	signalEMA  *EMA
-	macdValues []float64
-	signalLine []float64
-	histograms []float64
+	maType       MAType
+	signalMAType MAType
+	fastMA       MovingAverage
+	slowMA       MovingAverage
+	signalMA     MovingAverage
+	macdValues   []float64
+	signalLine   []float64
+	histograms   []float64
 }
 
 // MACDOutput represents the output of MACD calculations
@@ -18,11 +20,30 @@ type MACDOutput struct {
 	Histogram float64
 }
 
+// MACDOption configures optional MACD parameters
+type MACDOption func(*MACD)
+
+// WithMACDMAType sets the moving average used for the fast and slow lines
+// (default MATypeEMA)
+func WithMACDMAType(maType MAType) MACDOption {
+	return func(macd *MACD) {
+		macd.maType = maType
+	}
+}
+
+// WithMACDSignalMAType sets the moving average used for the signal line
+// (default MATypeEMA)
+func WithMACDSignalMAType(maType MAType) MACDOption {
+	return func(macd *MACD) {
+		macd.signalMAType = maType
+	}
+}
+
 // NewMACD creates a new MACD indicator with specified parameters
 // fastLength: the period for the fast EMA (default 12)
 // slowLength: the period for the slow EMA (default 26)
 // signalLength: the period for the signal line EMA (default 9)
-func NewMACD(fastLength, slowLength, signalLength int) *MACD {
+func NewMACD(fastLength, slowLength, signalLength int, opts ...MACDOption) *MACD {
 	if fastLength <= 0 || slowLength <= 0 || signalLength <= 0 {
 		panic("All periods must be greater than 0")
 	}
@@ -31,40 +52,47 @@ func NewMACD(fastLength, slowLength, signalLength int) *MACD {
 		panic("Fast length must be less than slow length")
 	}
 
-	return &MACD{
+	macd := &MACD{
 		BaseIndicator: NewBaseIndicator("MACD"),
-		fastEMA:       NewEMA(fastLength),
-		slowEMA:       NewEMA(slowLength),
-		signalEMA:     NewEMA(signalLength),
+		maType:        MATypeEMA,
+		signalMAType:  MATypeEMA,
 		macdValues:    make([]float64, 0),
 		signalLine:    make([]float64, 0),
 		histograms:    make([]float64, 0),
 	}
+	for _, opt := range opts {
+		opt(macd)
+	}
+	macd.fastMA = NewMovingAverage(macd.maType, fastLength)
+	macd.slowMA = NewMovingAverage(macd.maType, slowLength)
+	macd.signalMA = NewMovingAverage(macd.signalMAType, signalLength)
+
+	return macd
 }
 
 // AddValue adds a new value to the MACD calculation
 func (macd *MACD) AddValue(value float64) {
 	macd.input = append(macd.input, value)
 
-	// Add value to both EMAs
-	macd.fastEMA.AddValue(value)
-	macd.slowEMA.AddValue(value)
+	// Add value to both moving averages
+	macd.fastMA.AddValue(value)
+	macd.slowMA.AddValue(value)
 
-	// If both EMAs have outputs, calculate MACD line
-	if macd.fastEMA.IsInitialized() && macd.slowEMA.IsInitialized() {
-		fastValue, _ := macd.fastEMA.GetLastValue()
-		slowValue, _ := macd.slowEMA.GetLastValue()
+	// If both moving averages have outputs, calculate MACD line
+	if macd.fastMA.IsInitialized() && macd.slowMA.IsInitialized() {
+		fastValue, _ := macd.fastMA.GetLastValue()
+		slowValue, _ := macd.slowMA.GetLastValue()
 
-		// MACD line = fast EMA - slow EMA
+		// MACD line = fast MA - slow MA
 		macdValue := fastValue - slowValue
 		macd.macdValues = append(macd.macdValues, macdValue)
 
-		// Feed the MACD value into the signal EMA
-		macd.signalEMA.AddValue(macdValue)
+		// Feed the MACD value into the signal moving average
+		macd.signalMA.AddValue(macdValue)
 
-		// If the signal EMA has an output, calculate histogram
-		if macd.signalEMA.IsInitialized() {
-			signalValue, _ := macd.signalEMA.GetLastValue()
+		// If the signal moving average has an output, calculate histogram
+		if macd.signalMA.IsInitialized() {
+			signalValue, _ := macd.signalMA.GetLastValue()
 			macd.signalLine = append(macd.signalLine, signalValue)
 
 			// Histogram = MACD line - signal line
@@ -80,26 +108,30 @@ func (macd *MACD) AddValue(value float64) {
 	}
 }
 
+// Reset clears all values in the MACD
+func (macd *MACD) Reset() {
+	macd.BaseIndicator.Reset()
+	macd.fastMA.Reset()
+	macd.slowMA.Reset()
+	macd.signalMA.Reset()
+	macd.macdValues = make([]float64, 0)
+	macd.signalLine = make([]float64, 0)
+	macd.histograms = make([]float64, 0)
+}
+
 // GetMACDOutput returns the complete MACD output (MACD, Signal, Histogram)
+// for every value that has a signal line
 func (macd *MACD) GetMACDOutput() []MACDOutput {
-	results := make([]MACDOutput, 0)
+	// The MACD line starts before the signal line
+	macdStart := len(macd.macdValues) - len(macd.signalLine)
 
-	// Get the minimum length of the three slices
-	minLength := len(macd.macdValues)
-	if len(macd.signalLine) < minLength {
-		minLength = len(macd.signalLine)
-	}
-	if len(macd.histograms) < minLength {
-		minLength = len(macd.histograms)
-	}
-
-	// Build the output
-	for i := 0; i < minLength; i++ {
-		results = append(results, MACDOutput{
-			MACD:      macd.macdValues[i],
+	results := make([]MACDOutput, len(macd.signalLine))
+	for i := range macd.signalLine {
+		results[i] = MACDOutput{
+			MACD:      macd.macdValues[macdStart+i],
 			Signal:    macd.signalLine[i],
 			Histogram: macd.histograms[i],
-		})
+		}
 	}
 
 	return results
@@ -0,0 +1,67 @@
+package indicators
+
+import (
+	"math"
+)
+
+// McGinley represents the McGinley Dynamic indicator, a moving average that
+// adjusts its speed to the pace of the market
+type McGinley struct {
+	*BaseIndicator
+	windowSize int
+	sma        *SMA
+	lastValue  float64
+}
+
+// NewMcGinley creates a new McGinley Dynamic indicator with specified window size
+func NewMcGinley(windowSize int) *McGinley {
+	if windowSize <= 0 {
+		panic("Window size must be greater than 0")
+	}
+
+	return &McGinley{
+		BaseIndicator: NewBaseIndicator("McGinley"),
+		windowSize:    windowSize,
+		sma:           NewSMA(windowSize),
+	}
+}
+
+// AddValue adds a new value to the McGinley Dynamic calculation
+func (mg *McGinley) AddValue(value float64) {
+	if !mg.IsInitialized() {
+		// Seed with the SMA of the first window
+		mg.sma.AddValue(value)
+		if mg.sma.IsInitialized() {
+			mg.lastValue, _ = mg.sma.GetLastValue()
+			mg.AddOutput(mg.lastValue)
+		}
+		return
+	}
+
+	// MD = MD[1] + (value - MD[1]) / (windowSize * (value / MD[1])^4)
+	//
+	// The ratio is clamped to [1/windowSize, windowSize], so a value close to
+	// or across zero neither overshoots the value nor stalls the average: the
+	// new MD always lies between MD[1] and the value.
+	n := float64(mg.windowSize)
+	ratio := math.Pow(value/mg.lastValue, 4)
+	if math.IsNaN(ratio) {
+		ratio = 1
+	}
+	ratio = math.Max(1/n, math.Min(ratio, n))
+	step := (value - mg.lastValue) / (n * ratio)
+	mg.lastValue += step
+	mg.AddOutput(mg.lastValue)
+}
+
+// GetWindowSize returns the window size of the McGinley Dynamic
+func (mg *McGinley) GetWindowSize() int {
+	return mg.windowSize
+}
+
+// Reset clears all values in the McGinley Dynamic
+func (mg *McGinley) Reset() {
+	mg.BaseIndicator.Reset()
+	mg.sma.Reset()
+	mg.lastValue = 0
+}
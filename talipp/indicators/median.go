@@ -0,0 +1,55 @@
+package indicators
+
+import "math"
+
+// Median represents a rolling median indicator. The median of a window
+// holding NaN values is NaN.
+type Median struct {
+	*BaseIndicator
+	windowSize int
+	window     *sortedWindow
+}
+
+// NewMedian creates a new rolling median indicator with specified window size (default 20)
+func NewMedian(windowSize int) *Median {
+	if windowSize <= 0 {
+		panic("Window size must be greater than 0")
+	}
+
+	return &Median{
+		BaseIndicator: NewBaseIndicator("Median"),
+		windowSize:    windowSize,
+		window:        newSortedWindow(windowSize),
+	}
+}
+
+// AddValue adds a new value to the median calculation
+func (median *Median) AddValue(value float64) {
+	median.window.Add(value)
+
+	if median.window.Full() {
+		if median.window.HasNaN() {
+			median.AddOutput(math.NaN())
+			return
+		}
+
+		// The median of an even window is the average of the two middle values
+		middle := median.windowSize / 2
+		if median.windowSize%2 == 1 {
+			median.AddOutput(median.window.Sorted(middle))
+		} else {
+			median.AddOutput((median.window.Sorted(middle-1) + median.window.Sorted(middle)) / 2.0)
+		}
+	}
+}
+
+// GetWindowSize returns the window size of the Median
+func (median *Median) GetWindowSize() int {
+	return median.windowSize
+}
+
+// Reset clears all values in the Median
+func (median *Median) Reset() {
+	median.BaseIndicator.Reset()
+	median.window.Reset()
+}
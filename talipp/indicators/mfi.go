@@ -0,0 +1,88 @@
+package indicators
+
+import (
+	"github.com/revanthstrakz/gotalipp/talipp/ohlcv"
+)
+
+// MFI represents the Money Flow Index indicator
+type MFI struct {
+	*BaseIndicator
+	windowSize    int
+	prevTypical   float64
+	firstValueSet bool
+	positiveFlow  *rollingStats
+	negativeFlow  *rollingStats
+}
+
+// NewMFI creates a new Money Flow Index indicator with specified window size (default 14)
+func NewMFI(windowSize int) *MFI {
+	if windowSize <= 0 {
+		panic("Window size must be greater than 0")
+	}
+
+	return &MFI{
+		BaseIndicator: NewBaseIndicator("MFI"),
+		windowSize:    windowSize,
+		positiveFlow:  newRollingStats(windowSize),
+		negativeFlow:  newRollingStats(windowSize),
+	}
+}
+
+// AddCandle adds a new candle to the MFI calculation
+func (mfi *MFI) AddCandle(candle *ohlcv.OHLCV) {
+	mfi.AddHLCVValue(candle.High, candle.Low, candle.Close, candle.Volume)
+}
+
+// AddHLCVValue adds a new high, low, close and volume to the MFI calculation
+func (mfi *MFI) AddHLCVValue(high, low, close, volume float64) {
+	typical := (high + low + close) / 3.0
+	if !mfi.firstValueSet {
+		// Money flow direction needs a previous typical price
+		mfi.prevTypical = typical
+		mfi.firstValueSet = true
+		return
+	}
+
+	// Raw money flow is positive on rising and negative on falling typical price
+	rawFlow := typical * volume
+	var positive, negative float64
+	if typical > mfi.prevTypical {
+		positive = rawFlow
+	} else if typical < mfi.prevTypical {
+		negative = rawFlow
+	}
+	mfi.positiveFlow.Add(positive)
+	mfi.negativeFlow.Add(negative)
+	mfi.prevTypical = typical
+
+	if mfi.positiveFlow.Full() {
+		// MFI = 100 - 100 / (1 + positive flow / negative flow)
+		negativeSum := mfi.negativeFlow.Sum()
+		if negativeSum == 0 {
+			mfi.AddOutput(100.0)
+			return
+		}
+		mfi.AddOutput(100.0 - 100.0/(1.0+mfi.positiveFlow.Sum()/negativeSum))
+	}
+}
+
+// AddValue is not the preferred method for MFI, but can be used for compatibility
+// with the Indicator interface. It will use the value as high, low and close with a
+// volume of 1.
+func (mfi *MFI) AddValue(value float64) {
+	mfi.AddHLCVValue(value, value, value, 1.0)
+}
+
+// GetWindowSize returns the window size of the MFI
+func (mfi *MFI) GetWindowSize() int {
+	return mfi.windowSize
+}
+
+// Reset clears all values in the MFI
+func (mfi *MFI) Reset() {
+	mfi.BaseIndicator.Reset()
+	mfi.prevTypical = 0
+	mfi.firstValueSet = false
+	mfi.positiveFlow.Reset()
+	mfi.negativeFlow.Reset()
+}
@@ -0,0 +1,81 @@
+package indicators
+
+// RollingMax represents the highest value over a sliding window
+type RollingMax struct {
+	*BaseIndicator
+	windowSize int
+	extremes   *rollingExtremes
+}
+
+// NewRollingMax creates a new rolling maximum indicator with specified window size
+func NewRollingMax(windowSize int) *RollingMax {
+	if windowSize <= 0 {
+		panic("Window size must be greater than 0")
+	}
+
+	return &RollingMax{
+		BaseIndicator: NewBaseIndicator("RollingMax"),
+		windowSize:    windowSize,
+		extremes:      newRollingExtremes(windowSize),
+	}
+}
+
+// AddValue adds a new value to the rolling maximum calculation
+func (rm *RollingMax) AddValue(value float64) {
+	rm.extremes.Add(value, value)
+
+	if rm.extremes.Full() {
+		rm.AddOutput(rm.extremes.Max())
+	}
+}
+
+// GetWindowSize returns the window size of the RollingMax
+func (rm *RollingMax) GetWindowSize() int {
+	return rm.windowSize
+}
+
+// Reset clears all values in the RollingMax
+func (rm *RollingMax) Reset() {
+	rm.BaseIndicator.Reset()
+	rm.extremes.Reset()
+}
+
+// RollingMin represents the lowest value over a sliding window
+type RollingMin struct {
+	*BaseIndicator
+	windowSize int
+	extremes   *rollingExtremes
+}
+
+// NewRollingMin creates a new rolling minimum indicator with specified window size
+func NewRollingMin(windowSize int) *RollingMin {
+	if windowSize <= 0 {
+		panic("Window size must be greater than 0")
+	}
+
+	return &RollingMin{
+		BaseIndicator: NewBaseIndicator("RollingMin"),
+		windowSize:    windowSize,
+		extremes:      newRollingExtremes(windowSize),
+	}
+}
+
+// AddValue adds a new value to the rolling minimum calculation
+func (rm *RollingMin) AddValue(value float64) {
+	rm.extremes.Add(value, value)
+
+	if rm.extremes.Full() {
+		rm.AddOutput(rm.extremes.Min())
+	}
+}
+
+// GetWindowSize returns the window size of the RollingMin
+func (rm *RollingMin) GetWindowSize() int {
+	return rm.windowSize
+}
+
+// Reset clears all values in the RollingMin
+func (rm *RollingMin) Reset() {
+	rm.BaseIndicator.Reset()
+	rm.extremes.Reset()
+}
@@ -0,0 +1,47 @@
+package indicators
+
+// Momentum represents the Momentum indicator, the change of the value over
+// windowSize periods
+type Momentum struct {
+	*BaseIndicator
+	windowSize int
+	values     []float64
+}
+
+// NewMomentum creates a new Momentum indicator with specified window size (default 10)
+func NewMomentum(windowSize int) *Momentum {
+	if windowSize <= 0 {
+		panic("Window size must be greater than 0")
+	}
+
+	return &Momentum{
+		BaseIndicator: NewBaseIndicator("Momentum"),
+		windowSize:    windowSize,
+		values:        make([]float64, 0),
+	}
+}
+
+// AddValue adds a new value to the Momentum calculation
+func (mom *Momentum) AddValue(value float64) {
+	mom.values = append(mom.values, value)
+
+	// Keep the value windowSize periods ago
+	if len(mom.values) > mom.windowSize+1 {
+		mom.values = mom.values[1:]
+	}
+
+	if len(mom.values) == mom.windowSize+1 {
+		mom.AddOutput(value - mom.values[0])
+	}
+}
+
+// GetWindowSize returns the window size of the Momentum
+func (mom *Momentum) GetWindowSize() int {
+	return mom.windowSize
+}
+
+// Reset clears all values in the Momentum
+func (mom *Momentum) Reset() {
+	mom.BaseIndicator.Reset()
+	mom.values = make([]float64, 0)
+}
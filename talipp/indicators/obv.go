@@ -0,0 +1,55 @@
+package indicators
+
+import (
+	"github.com/revanthstrakz/gotalipp/talipp/ohlcv"
+)
+
+// OBV represents the On-Balance Volume indicator
+type OBV struct {
+	*BaseIndicator
+	prevClose     float64
+	firstValueSet bool
+	lastValue     float64
+}
+
+// NewOBV creates a new On-Balance Volume indicator
+func NewOBV() *OBV {
+	return &OBV{
+		BaseIndicator: NewBaseIndicator("OBV"),
+	}
+}
+
+// AddCandle adds a new candle to the OBV calculation
+func (obv *OBV) AddCandle(candle *ohlcv.OHLCV) {
+	obv.AddCloseVolumeValue(candle.Close, candle.Volume)
+}
+
+// AddCloseVolumeValue adds a new close and volume to the OBV calculation
+func (obv *OBV) AddCloseVolumeValue(close, volume float64) {
+	if obv.firstValueSet {
+		// Volume is added on up closes and subtracted on down closes
+		if close > obv.prevClose {
+			obv.lastValue += volume
+		} else if close < obv.prevClose {
+			obv.lastValue -= volume
+		}
+	}
+
+	obv.prevClose = close
+	obv.firstValueSet = true
+	obv.AddOutput(obv.lastValue)
+}
+
+// AddValue is not the preferred method for OBV, but can be used for compatibility
+// with the Indicator interface. Every value gets a volume of 1.
+func (obv *OBV) AddValue(value float64) {
+	obv.AddCloseVolumeValue(value, 1.0)
+}
+
+// Reset clears all values in the OBV
+func (obv *OBV) Reset() {
+	obv.BaseIndicator.Reset()
+	obv.prevClose = 0
+	obv.firstValueSet = false
+	obv.lastValue = 0
+}
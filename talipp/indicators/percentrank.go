@@ -0,0 +1,51 @@
+package indicators
+
+import "math"
+
+// PercentRank represents a rolling percentile rank indicator: the percentage
+// of the previous windowSize values that are less than or equal to the
+// latest value, ranging from 0 to 100. The rank is NaN when the latest or any
+// of the previous values is NaN.
+type PercentRank struct {
+	*BaseIndicator
+	windowSize int
+	window     *sortedWindow
+}
+
+// NewPercentRank creates a new percentile rank indicator with specified window size (default 20)
+func NewPercentRank(windowSize int) *PercentRank {
+	if windowSize <= 0 {
+		panic("Window size must be greater than 0")
+	}
+
+	return &PercentRank{
+		BaseIndicator: NewBaseIndicator("PercentRank"),
+		windowSize:    windowSize,
+		window:        newSortedWindow(windowSize),
+	}
+}
+
+// AddValue adds a new value to the percentile rank calculation
+func (pr *PercentRank) AddValue(value float64) {
+	// The latest value is ranked against the previous values only
+	if pr.window.Full() {
+		if math.IsNaN(value) || pr.window.HasNaN() {
+			pr.AddOutput(math.NaN())
+		} else {
+			pr.AddOutput(100.0 * float64(pr.window.CountLessOrEqual(value)) / float64(pr.windowSize))
+		}
+	}
+
+	pr.window.Add(value)
+}
+
+// GetWindowSize returns the window size of the PercentRank
+func (pr *PercentRank) GetWindowSize() int {
+	return pr.windowSize
+}
+
+// Reset clears all values in the PercentRank
+func (pr *PercentRank) Reset() {
+	pr.BaseIndicator.Reset()
+	pr.window.Reset()
+}
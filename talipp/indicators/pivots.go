@@ -0,0 +1,287 @@
+package indicators
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/revanthstrakz/gotalipp/talipp/ohlcv"
+)
+
+// PivotType identifies a pivot point formula
+type PivotType int
+
+const (
+	// PivotClassic selects the classic (floor) pivot points with three
+	// resistance and three support levels
+	PivotClassic PivotType = iota
+	// PivotFibonacci selects pivot points with levels at Fibonacci ratios of
+	// the prior range, three on each side
+	PivotFibonacci
+	// PivotCamarilla selects the Camarilla levels around the prior close, four
+	// on each side
+	PivotCamarilla
+	// PivotWoodie selects Woodie's pivot points, which weight the prior close
+	// twice, with three levels on each side
+	PivotWoodie
+	// PivotDeMark selects DeMark's pivot points, which depend on how the prior
+	// period closed relative to its open, with one level on each side
+	PivotDeMark
+)
+
+// String returns the name of the pivot type
+func (t PivotType) String() string {
+	switch t {
+	case PivotClassic:
+		return "Classic"
+	case PivotFibonacci:
+		return "Fibonacci"
+	case PivotCamarilla:
+		return "Camarilla"
+	case PivotWoodie:
+		return "Woodie"
+	case PivotDeMark:
+		return "DeMark"
+	default:
+		return fmt.Sprintf("PivotType(%d)", int(t))
+	}
+}
+
+// PivotLevels represents the pivot levels of one period, computed from the
+// open, high, low and close of the prior period
+type PivotLevels struct {
+	// Period is the start of the period the levels apply to
+	Period time.Time
+	Pivot  float64
+	// Resistance holds R1, R2, ... in increasing order of price
+	Resistance []float64
+	// Support holds S1, S2, ... in decreasing order of price
+	Support []float64
+}
+
+// Pivots represents pivot points computed from the prior period of an
+// intraday stream. Candles are grouped into daily, weekly or monthly periods
+// by timestamp; when a candle opens a new period the levels of that period
+// are computed from the open, high, low and close of the period just ended.
+//
+// The base indicator output is the pivot of the current period for every
+// candle added once the first period is complete.
+type Pivots struct {
+	*BaseIndicator
+	pivotType     PivotType
+	period        SessionPeriod
+	location      *time.Location
+	sessionStart  time.Duration
+	currentPeriod time.Time
+	periodSet     bool
+	open          float64
+	high          float64
+	low           float64
+	close         float64
+	hasPrices     bool
+	levels        []PivotLevels
+	onLevels      func(PivotLevels)
+}
+
+// PivotOption configures optional Pivots parameters
+type PivotOption func(*Pivots)
+
+// WithPivotPeriod sets the period the levels are computed over (default SessionDaily)
+func WithPivotPeriod(period SessionPeriod) PivotOption {
+	return func(pivots *Pivots) {
+		pivots.period = period
+	}
+}
+
+// WithPivotSession sets when periods start: every day at the given offset
+// from midnight in location (default midnight UTC). Weekly periods start on
+// Monday and monthly periods on the first day of the month at that time.
+func WithPivotSession(location *time.Location, start time.Duration) PivotOption {
+	return func(pivots *Pivots) {
+		pivots.location = location
+		pivots.sessionStart = start
+	}
+}
+
+// NewPivots creates a new pivot points indicator with the specified formula
+func NewPivots(pivotType PivotType, opts ...PivotOption) *Pivots {
+	switch pivotType {
+	case PivotClassic, PivotFibonacci, PivotCamarilla, PivotWoodie, PivotDeMark:
+	default:
+		panic(fmt.Sprintf("Unknown pivot type: %s", pivotType))
+	}
+
+	pivots := &Pivots{
+		BaseIndicator: NewBaseIndicator(pivotType.String() + "Pivots"),
+		pivotType:     pivotType,
+		period:        SessionDaily,
+		location:      time.UTC,
+		levels:        make([]PivotLevels, 0),
+	}
+	for _, opt := range opts {
+		opt(pivots)
+	}
+	if pivots.location == nil {
+		panic("Session location must not be nil")
+	}
+
+	return pivots
+}
+
+// OnLevels sets a callback to be called with the levels of every new period
+func (pivots *Pivots) OnLevels(callback func(PivotLevels)) {
+	pivots.onLevels = callback
+}
+
+// AddCandle adds a new candle to the pivot points calculation
+func (pivots *Pivots) AddCandle(candle *ohlcv.OHLCV) {
+	period := sessionStart(candle.Timestamp, pivots.location, pivots.sessionStart, pivots.period)
+	if !pivots.periodSet || !period.Equal(pivots.currentPeriod) {
+		if pivots.periodSet {
+			pivots.rollover(period)
+		}
+		pivots.currentPeriod = period
+		pivots.periodSet = true
+	}
+
+	pivots.addPrices(candle.Open, candle.High, candle.Low, candle.Close)
+}
+
+// AddValue is not the preferred method for Pivots, but can be used for compatibility
+// with the Indicator interface. It will use the value as open, high, low and close
+// of a candle of the current period; use Rollover to start a new period.
+func (pivots *Pivots) AddValue(value float64) {
+	pivots.addPrices(value, value, value, value)
+}
+
+// Rollover ends the current period manually and computes the levels of the
+// next one, for data without timestamps or custom sessions
+func (pivots *Pivots) Rollover() {
+	pivots.rollover(time.Time{})
+}
+
+// addPrices adds a candle to the current period
+func (pivots *Pivots) addPrices(open, high, low, close float64) {
+	if !pivots.hasPrices {
+		pivots.open = open
+		pivots.high = high
+		pivots.low = low
+		pivots.hasPrices = true
+	}
+	pivots.high = math.Max(pivots.high, high)
+	pivots.low = math.Min(pivots.low, low)
+	pivots.close = close
+
+	if len(pivots.levels) > 0 {
+		pivots.AddOutput(pivots.levels[len(pivots.levels)-1].Pivot)
+	}
+}
+
+// rollover computes the levels of the period starting at next from the
+// current period
+func (pivots *Pivots) rollover(next time.Time) {
+	if !pivots.hasPrices {
+		return
+	}
+
+	levels := calculatePivotLevels(pivots.pivotType, pivots.open, pivots.high, pivots.low, pivots.close)
+	levels.Period = next
+	pivots.levels = append(pivots.levels, levels)
+	pivots.hasPrices = false
+
+	if pivots.onLevels != nil {
+		pivots.onLevels(levels)
+	}
+}
+
+// calculatePivotLevels computes the pivot levels from the prior period prices
+func calculatePivotLevels(pivotType PivotType, open, high, low, close float64) PivotLevels {
+	priorRange := high - low
+
+	switch pivotType {
+	case PivotFibonacci:
+		pivot := (high + low + close) / 3.0
+		return PivotLevels{
+			Pivot:      pivot,
+			Resistance: []float64{pivot + 0.382*priorRange, pivot + 0.618*priorRange, pivot + priorRange},
+			Support:    []float64{pivot - 0.382*priorRange, pivot - 0.618*priorRange, pivot - priorRange},
+		}
+
+	case PivotCamarilla:
+		levels := PivotLevels{
+			Pivot:      (high + low + close) / 3.0,
+			Resistance: make([]float64, 4),
+			Support:    make([]float64, 4),
+		}
+		for i, divisor := range []float64{12, 6, 4, 2} {
+			levels.Resistance[i] = close + priorRange*1.1/divisor
+			levels.Support[i] = close - priorRange*1.1/divisor
+		}
+		return levels
+
+	case PivotDeMark:
+		var x float64
+		switch {
+		case close < open:
+			x = high + 2*low + close
+		case close > open:
+			x = 2*high + low + close
+		default:
+			x = high + low + 2*close
+		}
+		return PivotLevels{
+			Pivot:      x / 4.0,
+			Resistance: []float64{x/2.0 - low},
+			Support:    []float64{x/2.0 - high},
+		}
+
+	default:
+		pivot := (high + low + close) / 3.0
+		if pivotType == PivotWoodie {
+			pivot = (high + low + 2*close) / 4.0
+		}
+		return PivotLevels{
+			Pivot:      pivot,
+			Resistance: []float64{2*pivot - low, pivot + priorRange, high + 2*(pivot-low)},
+			Support:    []float64{2*pivot - high, pivot - priorRange, low - 2*(high-pivot)},
+		}
+	}
+}
+
+// GetLevels returns the levels of the current period, or false before the
+// first period is complete
+func (pivots *Pivots) GetLevels() (PivotLevels, bool) {
+	if len(pivots.levels) == 0 {
+		return PivotLevels{}, false
+	}
+	return copyPivotLevels(pivots.levels[len(pivots.levels)-1]), true
+}
+
+// GetPivotOutput returns the levels of every period since the first complete one
+func (pivots *Pivots) GetPivotOutput() []PivotLevels {
+	result := make([]PivotLevels, len(pivots.levels))
+	for i, levels := range pivots.levels {
+		result[i] = copyPivotLevels(levels)
+	}
+	return result
+}
+
+// copyPivotLevels returns a copy of the levels that does not share their slices
+func copyPivotLevels(levels PivotLevels) PivotLevels {
+	levels.Resistance = append([]float64(nil), levels.Resistance...)
+	levels.Support = append([]float64(nil), levels.Support...)
+	return levels
+}
+
+// Reset clears all values in the Pivots
+func (pivots *Pivots) Reset() {
+	pivots.BaseIndicator.Reset()
+	pivots.currentPeriod = time.Time{}
+	pivots.periodSet = false
+	pivots.open = 0
+	pivots.high = 0
+	pivots.low = 0
+	pivots.close = 0
+	pivots.hasPrices = false
+	pivots.levels = make([]PivotLevels, 0)
+}
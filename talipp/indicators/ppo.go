@@ -0,0 +1,147 @@
+package indicators
+
+// PPO represents the Percentage Price Oscillator indicator, the MACD expressed
+// as a percentage of the slow moving average
+type PPO struct {
+	*BaseIndicator
+	maType       MAType
+	signalMAType MAType
+	fastMA       MovingAverage
+	slowMA       MovingAverage
+	signalMA     MovingAverage
+	ppoValues    []float64
+	signalLine   []float64
+	histograms   []float64
+}
+
+// PPOOutput represents the output of PPO calculations
+type PPOOutput struct {
+	PPO       float64
+	Signal    float64
+	Histogram float64
+}
+
+// PPOOption configures optional PPO parameters
+type PPOOption func(*PPO)
+
+// WithPPOMAType sets the moving average used for the fast and slow lines (default MATypeEMA)
+func WithPPOMAType(maType MAType) PPOOption {
+	return func(ppo *PPO) {
+		ppo.maType = maType
+	}
+}
+
+// WithPPOSignalMAType sets the moving average used for the signal line (default MATypeEMA)
+func WithPPOSignalMAType(maType MAType) PPOOption {
+	return func(ppo *PPO) {
+		ppo.signalMAType = maType
+	}
+}
+
+// NewPPO creates a new Percentage Price Oscillator indicator
+// fastLength: the period for the fast moving average (default 12)
+// slowLength: the period for the slow moving average (default 26)
+// signalLength: the period for the signal line (default 9)
+func NewPPO(fastLength, slowLength, signalLength int, opts ...PPOOption) *PPO {
+	if fastLength <= 0 || slowLength <= 0 || signalLength <= 0 {
+		panic("All periods must be greater than 0")
+	}
+
+	if fastLength >= slowLength {
+		panic("Fast length must be less than slow length")
+	}
+
+	ppo := &PPO{
+		BaseIndicator: NewBaseIndicator("PPO"),
+		maType:        MATypeEMA,
+		signalMAType:  MATypeEMA,
+		ppoValues:     make([]float64, 0),
+		signalLine:    make([]float64, 0),
+		histograms:    make([]float64, 0),
+	}
+	for _, opt := range opts {
+		opt(ppo)
+	}
+	ppo.fastMA = NewMovingAverage(ppo.maType, fastLength)
+	ppo.slowMA = NewMovingAverage(ppo.maType, slowLength)
+	ppo.signalMA = NewMovingAverage(ppo.signalMAType, signalLength)
+
+	return ppo
+}
+
+// AddValue adds a new value to the PPO calculation
+func (ppo *PPO) AddValue(value float64) {
+	ppo.fastMA.AddValue(value)
+	ppo.slowMA.AddValue(value)
+
+	if !ppo.fastMA.IsInitialized() || !ppo.slowMA.IsInitialized() {
+		return
+	}
+
+	// PPO = 100 * (fast MA - slow MA) / slow MA
+	fastValue, _ := ppo.fastMA.GetLastValue()
+	slowValue, _ := ppo.slowMA.GetLastValue()
+	var ppoValue float64
+	if slowValue != 0 {
+		ppoValue = 100.0 * (fastValue - slowValue) / slowValue
+	}
+	ppo.ppoValues = append(ppo.ppoValues, ppoValue)
+	ppo.AddOutput(ppoValue)
+
+	ppo.signalMA.AddValue(ppoValue)
+	if ppo.signalMA.IsInitialized() {
+		signalValue, _ := ppo.signalMA.GetLastValue()
+		ppo.signalLine = append(ppo.signalLine, signalValue)
+		ppo.histograms = append(ppo.histograms, ppoValue-signalValue)
+	}
+}
+
+// Reset clears all values in the PPO
+func (ppo *PPO) Reset() {
+	ppo.BaseIndicator.Reset()
+	ppo.fastMA.Reset()
+	ppo.slowMA.Reset()
+	ppo.signalMA.Reset()
+	ppo.ppoValues = make([]float64, 0)
+	ppo.signalLine = make([]float64, 0)
+	ppo.histograms = make([]float64, 0)
+}
+
+// GetPPOOutput returns the complete PPO output (PPO, Signal, Histogram) for
+// every value that has a signal line
+func (ppo *PPO) GetPPOOutput() []PPOOutput {
+	// The PPO line starts before the signal line
+	ppoStart := len(ppo.ppoValues) - len(ppo.signalLine)
+
+	results := make([]PPOOutput, len(ppo.signalLine))
+	for i := range ppo.signalLine {
+		results[i] = PPOOutput{
+			PPO:       ppo.ppoValues[ppoStart+i],
+			Signal:    ppo.signalLine[i],
+			Histogram: ppo.histograms[i],
+		}
+	}
+
+	return results
+}
+
+// GetPPOLine returns just the PPO line values
+func (ppo *PPO) GetPPOLine() []float64 {
+	result := make([]float64, len(ppo.ppoValues))
+	copy(result, ppo.ppoValues)
+	return result
+}
+
+// GetSignalLine returns just the signal line values
+func (ppo *PPO) GetSignalLine() []float64 {
+	result := make([]float64, len(ppo.signalLine))
+	copy(result, ppo.signalLine)
+	return result
+}
+
+// GetHistogram returns just the histogram values
+func (ppo *PPO) GetHistogram() []float64 {
+	result := make([]float64, len(ppo.histograms))
+	copy(result, ppo.histograms)
+	return result
+}
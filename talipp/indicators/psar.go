@@ -0,0 +1,155 @@
+package indicators
+
+import (
+	"math"
+)
+
+// PSAR represents the Parabolic Stop and Reverse indicator
+type PSAR struct {
+	*BaseIndicator
+	start       float64
+	increment   float64
+	maximum     float64
+	bars        int
+	prevHigh    [2]float64
+	prevLow     [2]float64
+	prevClose   float64
+	sar         float64
+	extreme     float64
+	accelFactor float64
+	trend       TrendDirection
+	results     []TrailingStopOutput
+}
+
+// NewPSAR creates a new Parabolic SAR indicator
+// start: the initial acceleration factor (default 0.02)
+// increment: the acceleration factor step on each new extreme (default 0.02)
+// maximum: the maximum acceleration factor (default 0.2)
+func NewPSAR(start, increment, maximum float64) *PSAR {
+	if start <= 0 || increment <= 0 || maximum <= 0 {
+		panic("All acceleration factors must be greater than 0")
+	}
+	if start > maximum {
+		panic("Start must not be greater than maximum")
+	}
+
+	return &PSAR{
+		BaseIndicator: NewBaseIndicator("PSAR"),
+		start:         start,
+		increment:     increment,
+		maximum:       maximum,
+		results:       make([]TrailingStopOutput, 0),
+	}
+}
+
+// AddOHLCValue adds a new OHLC candle data to the Parabolic SAR calculation
+func (psar *PSAR) AddOHLCValue(high, low, close float64) {
+	psar.bars++
+
+	switch {
+	case psar.bars == 1:
+		// The first candle only provides the starting extremes
+	case psar.bars == 2:
+		// The initial trend follows the direction of the first close-to-close move
+		psar.accelFactor = psar.start
+		if close >= psar.prevClose {
+			psar.trend = TrendUp
+			psar.sar = math.Min(psar.prevLow[0], low)
+			psar.extreme = math.Max(psar.prevHigh[0], high)
+		} else {
+			psar.trend = TrendDown
+			psar.sar = math.Max(psar.prevHigh[0], high)
+			psar.extreme = math.Min(psar.prevLow[0], low)
+		}
+		psar.addResult(false)
+	default:
+		psar.update(high, low)
+	}
+
+	psar.prevHigh[1] = psar.prevHigh[0]
+	psar.prevLow[1] = psar.prevLow[0]
+	psar.prevHigh[0] = high
+	psar.prevLow[0] = low
+	psar.prevClose = close
+}
+
+// update moves the SAR forward by one candle
+func (psar *PSAR) update(high, low float64) {
+	sar := psar.sar + psar.accelFactor*(psar.extreme-psar.sar)
+
+	if psar.trend == TrendUp {
+		// The SAR may not move into the range of the two previous candles
+		sar = math.Min(sar, math.Min(psar.prevLow[0], psar.prevLow[1]))
+
+		if low < sar {
+			// Reverse: the SAR jumps to the extreme of the finished trend
+			psar.trend = TrendDown
+			psar.sar = psar.extreme
+			psar.extreme = low
+			psar.accelFactor = psar.start
+			psar.addResult(true)
+			return
+		}
+
+		if high > psar.extreme {
+			psar.extreme = high
+			psar.accelFactor = math.Min(psar.accelFactor+psar.increment, psar.maximum)
+		}
+	} else {
+		sar = math.Max(sar, math.Max(psar.prevHigh[0], psar.prevHigh[1]))
+
+		if high > sar {
+			psar.trend = TrendUp
+			psar.sar = psar.extreme
+			psar.extreme = high
+			psar.accelFactor = psar.start
+			psar.addResult(true)
+			return
+		}
+
+		if low < psar.extreme {
+			psar.extreme = low
+			psar.accelFactor = math.Min(psar.accelFactor+psar.increment, psar.maximum)
+		}
+	}
+
+	psar.sar = sar
+	psar.addResult(false)
+}
+
+// addResult stores the SAR of the current candle
+func (psar *PSAR) addResult(flipped bool) {
+	psar.results = append(psar.results, TrailingStopOutput{
+		Stop:    psar.sar,
+		Trend:   psar.trend,
+		Flipped: flipped,
+	})
+	psar.AddOutput(psar.sar)
+}
+
+// AddValue is not the preferred method for PSAR, but can be used for compatibility
+// with the Indicator interface. It will use the value as high, low and close.
+func (psar *PSAR) AddValue(value float64) {
+	psar.AddOHLCValue(value, value, value)
+}
+
+// Reset clears all values in the PSAR
+func (psar *PSAR) Reset() {
+	psar.BaseIndicator.Reset()
+	psar.bars = 0
+	psar.prevHigh = [2]float64{}
+	psar.prevLow = [2]float64{}
+	psar.prevClose = 0
+	psar.sar = 0
+	psar.extreme = 0
+	psar.accelFactor = 0
+	psar.trend = 0
+	psar.results = make([]TrailingStopOutput, 0)
+}
+
+// GetPSAROutput returns the SAR level, trend direction and flip flag for every candle
+func (psar *PSAR) GetPSAROutput() []TrailingStopOutput {
+	result := make([]TrailingStopOutput, len(psar.results))
+	copy(result, psar.results)
+	return result
+}
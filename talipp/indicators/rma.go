@@ -0,0 +1,54 @@
+package indicators
+
+// RMA represents Wilder's smoothing, also known as the Running Moving Average
+// or SMMA. It is the smoothing used by ATR and RSI.
+type RMA struct {
+	*BaseIndicator
+	windowSize int
+	count      int
+	valueSum   float64
+	lastValue  float64
+}
+
+// NewRMA creates a new Wilder's smoothing indicator with specified window size
+func NewRMA(windowSize int) *RMA {
+	if windowSize <= 0 {
+		panic("Window size must be greater than 0")
+	}
+
+	return &RMA{
+		BaseIndicator: NewBaseIndicator("RMA"),
+		windowSize:    windowSize,
+	}
+}
+
+// AddValue adds a new value to the RMA calculation
+func (rma *RMA) AddValue(value float64) {
+	if !rma.IsInitialized() {
+		// The first value is the simple average of the first window
+		rma.count++
+		rma.valueSum += value
+		if rma.count == rma.windowSize {
+			rma.lastValue = rma.valueSum / float64(rma.windowSize)
+			rma.AddOutput(rma.lastValue)
+		}
+		return
+	}
+
+	// RMA = (previousRMA * (windowSize - 1) + value) / windowSize
+	rma.lastValue = ((rma.lastValue * float64(rma.windowSize-1)) + value) / float64(rma.windowSize)
+	rma.AddOutput(rma.lastValue)
+}
+
+// GetWindowSize returns the window size of the RMA
+func (rma *RMA) GetWindowSize() int {
+	return rma.windowSize
+}
+
+// Reset clears all values in the RMA
+func (rma *RMA) Reset() {
+	rma.BaseIndicator.Reset()
+	rma.count = 0
+	rma.valueSum = 0
+	rma.lastValue = 0
+}
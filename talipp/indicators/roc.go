@@ -0,0 +1,52 @@
+package indicators
+
+// ROC represents the Rate of Change indicator, the percentage change of the
+// value over windowSize periods
+type ROC struct {
+	*BaseIndicator
+	windowSize int
+	values     []float64
+}
+
+// NewROC creates a new Rate of Change indicator with specified window size (default 9)
+func NewROC(windowSize int) *ROC {
+	if windowSize <= 0 {
+		panic("Window size must be greater than 0")
+	}
+
+	return &ROC{
+		BaseIndicator: NewBaseIndicator("ROC"),
+		windowSize:    windowSize,
+		values:        make([]float64, 0),
+	}
+}
+
+// AddValue adds a new value to the ROC calculation
+func (roc *ROC) AddValue(value float64) {
+	roc.values = append(roc.values, value)
+
+	// Keep the value windowSize periods ago
+	if len(roc.values) > roc.windowSize+1 {
+		roc.values = roc.values[1:]
+	}
+
+	if len(roc.values) == roc.windowSize+1 {
+		// ROC = 100 * (value - value[n]) / value[n]
+		var rocValue float64
+		if previous := roc.values[0]; previous != 0 {
+			rocValue = 100.0 * (value - previous) / previous
+		}
+		roc.AddOutput(rocValue)
+	}
+}
+
+// GetWindowSize returns the window size of the ROC
+func (roc *ROC) GetWindowSize() int {
+	return roc.windowSize
+}
+
+// Reset clears all values in the ROC
+func (roc *ROC) Reset() {
+	roc.BaseIndicator.Reset()
+	roc.values = make([]float64, 0)
+}
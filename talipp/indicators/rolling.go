@@ -0,0 +1,443 @@
+package indicators
+
+import (
+	"math"
+	"sort"
+)
+
+// rollingResyncInterval is the minimum number of evictions between two full
+// recomputations of the rolling statistics from the window contents
+const rollingResyncInterval = 1024
+
+// rollingStats keeps numerically stable statistics over a sliding window.
+//
+// The running sum uses Neumaier compensated summation and the sum of squared
+// deviations is updated with the sliding-window form of Welford's algorithm.
+// Both are periodically recomputed from the window contents so that rounding
+// errors stay bounded no matter how many values are pushed through the window.
+// All operations are O(1) amortised.
+//
+// NaN and infinite values are only counted: while the window holds one, the
+// statistics are NaN, and they are recomputed once the last one is evicted.
+type rollingStats struct {
+	windowSize int
+	values     []float64
+	head       int
+	count      int
+	sum        float64
+	comp       float64
+	mean       float64
+	m2         float64
+	evictions  int
+	nonFinite  int
+}
+
+// newRollingStats creates a new rollingStats for the specified window size
+func newRollingStats(windowSize int) *rollingStats {
+	if windowSize <= 0 {
+		panic("Window size must be greater than 0")
+	}
+
+	return &rollingStats{
+		windowSize: windowSize,
+		values:     make([]float64, windowSize),
+	}
+}
+
+// Add pushes a new value into the window. If the window was already full the
+// oldest value is evicted and returned together with true.
+func (rs *rollingStats) Add(value float64) (float64, bool) {
+	if rs.count < rs.windowSize {
+		rs.values[(rs.head+rs.count)%rs.windowSize] = value
+		rs.count++
+		if !isFinite(value) {
+			rs.nonFinite++
+		}
+		if rs.nonFinite > 0 {
+			return 0, false
+		}
+		rs.addToSum(value)
+
+		// Regular Welford update while the window is filling up
+		delta := value - rs.mean
+		rs.mean += delta / float64(rs.count)
+		rs.m2 += delta * (value - rs.mean)
+		return 0, false
+	}
+
+	removed := rs.values[rs.head]
+	rs.values[rs.head] = value
+	rs.head = (rs.head + 1) % rs.windowSize
+
+	hadNonFinite := rs.nonFinite > 0
+	if !isFinite(removed) {
+		rs.nonFinite--
+	}
+	if !isFinite(value) {
+		rs.nonFinite++
+	}
+	if rs.nonFinite > 0 {
+		return removed, true
+	}
+	if hadNonFinite {
+		// The last non-finite value left the window
+		rs.resync()
+		return removed, true
+	}
+
+	rs.addToSum(value)
+	rs.addToSum(-removed)
+
+	// Sliding Welford update: replace the removed value by the new one
+	oldMean := rs.mean
+	rs.mean = rs.Sum() / float64(rs.count)
+	rs.m2 += (value - removed) * (value - rs.mean + removed - oldMean)
+	if rs.m2 < 0 {
+		rs.m2 = 0
+	}
+
+	rs.evictions++
+	if rs.evictions >= rs.windowSize && rs.evictions >= rollingResyncInterval {
+		rs.resync()
+	}
+
+	return removed, true
+}
+
+// isFinite returns whether value is neither NaN nor infinite
+func isFinite(value float64) bool {
+	return !math.IsNaN(value) && !math.IsInf(value, 0)
+}
+
+// HasNonFinite returns whether the window holds NaN or infinite values
+func (rs *rollingStats) HasNonFinite() bool {
+	return rs.nonFinite > 0
+}
+
+// addToSum adds a value to the compensated running sum
+func (rs *rollingStats) addToSum(value float64) {
+	t := rs.sum + value
+	if math.Abs(rs.sum) >= math.Abs(value) {
+		rs.comp += (rs.sum - t) + value
+	} else {
+		rs.comp += (value - t) + rs.sum
+	}
+	rs.sum = t
+}
+
+// resync recomputes the sum, mean and squared deviations from the window
+func (rs *rollingStats) resync() {
+	rs.sum = 0
+	rs.comp = 0
+	for i := 0; i < rs.count; i++ {
+		rs.addToSum(rs.At(i))
+	}
+	rs.mean = rs.Sum() / float64(rs.count)
+
+	var sumSq, compSq float64
+	for i := 0; i < rs.count; i++ {
+		d := rs.At(i) - rs.mean
+		term := d * d
+		t := sumSq + term
+		if sumSq >= term {
+			compSq += (sumSq - t) + term
+		} else {
+			compSq += (term - t) + sumSq
+		}
+		sumSq = t
+	}
+	rs.m2 = sumSq + compSq
+	rs.evictions = 0
+}
+
+// At returns the value at position i of the window, 0 being the oldest value
+func (rs *rollingStats) At(i int) float64 {
+	return rs.values[(rs.head+i)%rs.windowSize]
+}
+
+// Len returns the number of values currently held in the window
+func (rs *rollingStats) Len() int {
+	return rs.count
+}
+
+// Full returns whether the window holds windowSize values
+func (rs *rollingStats) Full() bool {
+	return rs.count == rs.windowSize
+}
+
+// Sum returns the compensated sum of the values in the window
+func (rs *rollingStats) Sum() float64 {
+	if rs.nonFinite > 0 {
+		return math.NaN()
+	}
+	return rs.sum + rs.comp
+}
+
+// Mean returns the mean of the values in the window
+func (rs *rollingStats) Mean() float64 {
+	if rs.count == 0 {
+		return 0
+	}
+	return rs.Sum() / float64(rs.count)
+}
+
+// Variance returns the population variance of the values in the window
+func (rs *rollingStats) Variance() float64 {
+	if rs.nonFinite > 0 {
+		return math.NaN()
+	}
+	if rs.count == 0 {
+		return 0
+	}
+	return rs.m2 / float64(rs.count)
+}
+
+// SampleVariance returns the sample (n-1) variance of the values in the window
+func (rs *rollingStats) SampleVariance() float64 {
+	if rs.nonFinite > 0 {
+		return math.NaN()
+	}
+	if rs.count < 2 {
+		return 0
+	}
+	return rs.m2 / float64(rs.count-1)
+}
+
+// StdDev returns the population standard deviation of the values in the window
+func (rs *rollingStats) StdDev() float64 {
+	return math.Sqrt(rs.Variance())
+}
+
+// Reset clears the window
+func (rs *rollingStats) Reset() {
+	rs.head = 0
+	rs.count = 0
+	rs.sum = 0
+	rs.comp = 0
+	rs.mean = 0
+	rs.m2 = 0
+	rs.evictions = 0
+	rs.nonFinite = 0
+}
+
+// sortedWindow keeps the values of a sliding window both in insertion order
+// and sorted, for order statistics such as the median or percentile rank.
+// Adding a value is O(log n) to locate plus a memmove of the sorted slice.
+//
+// NaN values take their slot in the window but are only counted, not sorted,
+// since they have no position in the order.
+type sortedWindow struct {
+	windowSize int
+	values     []float64
+	head       int
+	count      int
+	sorted     []float64
+	nans       int
+}
+
+// newSortedWindow creates a new sortedWindow for the specified window size
+func newSortedWindow(windowSize int) *sortedWindow {
+	if windowSize <= 0 {
+		panic("Window size must be greater than 0")
+	}
+
+	return &sortedWindow{
+		windowSize: windowSize,
+		values:     make([]float64, windowSize),
+		sorted:     make([]float64, 0, windowSize),
+	}
+}
+
+// Add pushes a new value into the window, evicting the oldest one if the window is full
+func (sw *sortedWindow) Add(value float64) {
+	if sw.count < sw.windowSize {
+		sw.values[(sw.head+sw.count)%sw.windowSize] = value
+		sw.count++
+	} else {
+		removed := sw.values[sw.head]
+		sw.values[sw.head] = value
+		sw.head = (sw.head + 1) % sw.windowSize
+
+		if math.IsNaN(removed) {
+			sw.nans--
+		} else {
+			// Any copy of an equal value can be removed
+			i := sort.SearchFloat64s(sw.sorted, removed)
+			sw.sorted = append(sw.sorted[:i], sw.sorted[i+1:]...)
+		}
+	}
+
+	if math.IsNaN(value) {
+		sw.nans++
+		return
+	}
+	i := sort.SearchFloat64s(sw.sorted, value)
+	sw.sorted = append(sw.sorted, 0)
+	copy(sw.sorted[i+1:], sw.sorted[i:])
+	sw.sorted[i] = value
+}
+
+// Full returns whether the window holds windowSize values
+func (sw *sortedWindow) Full() bool {
+	return sw.count == sw.windowSize
+}
+
+// HasNaN returns whether the window holds NaN values
+func (sw *sortedWindow) HasNaN() bool {
+	return sw.nans > 0
+}
+
+// Sorted returns the value at position i of the non-NaN values of the window in ascending order
+func (sw *sortedWindow) Sorted(i int) float64 {
+	return sw.sorted[i]
+}
+
+// CountLessOrEqual returns how many values of the window are less than or equal to value
+func (sw *sortedWindow) CountLessOrEqual(value float64) int {
+	return sort.Search(len(sw.sorted), func(i int) bool { return sw.sorted[i] > value })
+}
+
+// Reset clears the window
+func (sw *sortedWindow) Reset() {
+	sw.head = 0
+	sw.count = 0
+	sw.sorted = sw.sorted[:0]
+	sw.nans = 0
+}
+
+// rollingCovariance keeps the co-moment of two series over a sliding window,
+// together with the rolling statistics of each series.
+//
+// The co-moment is updated with the sliding-window form of Welford's algorithm
+// and periodically recomputed from the window contents, like rollingStats.
+type rollingCovariance struct {
+	windowSize int
+	first      *rollingStats
+	second     *rollingStats
+	comoment   float64
+	evictions  int
+}
+
+// newRollingCovariance creates a new rollingCovariance for the specified window size
+func newRollingCovariance(windowSize int) *rollingCovariance {
+	if windowSize <= 1 {
+		panic("Window size must be greater than 1")
+	}
+
+	return &rollingCovariance{
+		windowSize: windowSize,
+		first:      newRollingStats(windowSize),
+		second:     newRollingStats(windowSize),
+	}
+}
+
+// Add pushes a new pair of values into the window. While either series holds
+// a NaN or infinite value the co-moment is not updated; it is recomputed
+// from the window once the last such value is evicted.
+func (rc *rollingCovariance) Add(first, second float64) {
+	hadNonFinite := rc.hasNonFinite()
+	if !rc.first.Full() {
+		if hadNonFinite || !isFinite(first) || !isFinite(second) {
+			rc.first.Add(first)
+			rc.second.Add(second)
+			return
+		}
+
+		// Regular Welford update while the window is filling up
+		delta := first - rc.first.Mean()
+		rc.first.Add(first)
+		rc.second.Add(second)
+		rc.comoment += delta * (second - rc.second.Mean())
+		return
+	}
+
+	if hadNonFinite || !isFinite(first) || !isFinite(second) {
+		rc.first.Add(first)
+		rc.second.Add(second)
+		if hadNonFinite && !rc.hasNonFinite() {
+			rc.resync()
+		}
+		return
+	}
+
+	// Remove the oldest pair, then add the new one
+	n := float64(rc.windowSize)
+	oldFirst, oldSecond := rc.first.At(0), rc.second.At(0)
+	secondMean := rc.second.Mean()
+	firstMid := (rc.first.Sum() - oldFirst) / (n - 1)
+	rc.comoment -= (oldFirst - firstMid) * (oldSecond - secondMean)
+
+	rc.first.Add(first)
+	rc.second.Add(second)
+	rc.comoment += (first - firstMid) * (second - rc.second.Mean())
+
+	rc.evictions++
+	if rc.evictions >= rc.windowSize && rc.evictions >= rollingResyncInterval {
+		rc.resync()
+	}
+}
+
+// resync recomputes the co-moment from the window
+func (rc *rollingCovariance) resync() {
+	firstMean, secondMean := rc.first.Mean(), rc.second.Mean()
+	rc.comoment = 0
+	for i := 0; i < rc.first.Len(); i++ {
+		rc.comoment += (rc.first.At(i) - firstMean) * (rc.second.At(i) - secondMean)
+	}
+	rc.evictions = 0
+}
+
+// hasNonFinite returns whether either series holds NaN or infinite values
+func (rc *rollingCovariance) hasNonFinite() bool {
+	return rc.first.HasNonFinite() || rc.second.HasNonFinite()
+}
+
+// Full returns whether the window holds windowSize pairs
+func (rc *rollingCovariance) Full() bool {
+	return rc.first.Full()
+}
+
+// Covariance returns the population covariance of the window
+func (rc *rollingCovariance) Covariance() float64 {
+	if rc.hasNonFinite() {
+		return math.NaN()
+	}
+	if rc.first.Len() == 0 {
+		return 0
+	}
+	return rc.comoment / float64(rc.first.Len())
+}
+
+// SampleCovariance returns the sample (n-1) covariance of the window
+func (rc *rollingCovariance) SampleCovariance() float64 {
+	if rc.hasNonFinite() {
+		return math.NaN()
+	}
+	if rc.first.Len() < 2 {
+		return 0
+	}
+	return rc.comoment / float64(rc.first.Len()-1)
+}
+
+// Correlation returns the Pearson correlation coefficient of the window, 0
+// when either series is constant over the window
+func (rc *rollingCovariance) Correlation() float64 {
+	if rc.hasNonFinite() {
+		return math.NaN()
+	}
+	denominator := math.Sqrt(rc.first.Variance() * rc.second.Variance())
+	if denominator == 0 {
+		return 0
+	}
+
+	// Rounding can push the ratio slightly outside [-1, 1]
+	return math.Max(-1, math.Min(1, rc.Covariance()/denominator))
+}
+
+// Reset clears the window
+func (rc *rollingCovariance) Reset() {
+	rc.first.Reset()
+	rc.second.Reset()
+	rc.comoment = 0
+	rc.evictions = 0
+}
@@ -0,0 +1,154 @@
+package indicators
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recomputeStats computes the mean and population standard deviation of the
+// last windowSize values from scratch
+func recomputeStats(values []float64, windowSize int) (float64, float64) {
+	window := values[len(values)-windowSize:]
+	var sum float64
+	for _, v := range window {
+		sum += v
+	}
+	mean := sum / float64(windowSize)
+
+	var sq float64
+	for _, v := range window {
+		sq += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(sq / float64(windowSize))
+}
+
+func TestRollingStats(t *testing.T) {
+	t.Run("Basic rolling statistics", func(t *testing.T) {
+		rs := newRollingStats(3)
+
+		rs.Add(1.0)
+		rs.Add(2.0)
+		assert.False(t, rs.Full())
+		assert.InDelta(t, 1.5, rs.Mean(), 1e-12)
+
+		_, evicted := rs.Add(3.0)
+		assert.False(t, evicted)
+		assert.True(t, rs.Full())
+		assert.InDelta(t, 6.0, rs.Sum(), 1e-12)
+		assert.InDelta(t, 2.0/3.0, rs.Variance(), 1e-12)
+		assert.InDelta(t, 1.0, rs.SampleVariance(), 1e-12)
+
+		removed, evicted := rs.Add(7.0)
+		assert.True(t, evicted)
+		assert.Equal(t, 1.0, removed)
+		assert.InDelta(t, 4.0, rs.Mean(), 1e-12)
+		assert.InDelta(t, 14.0/3.0, rs.Variance(), 1e-12)
+		assert.Equal(t, 2.0, rs.At(0))
+		assert.Equal(t, 7.0, rs.At(2))
+
+		rs.Reset()
+		assert.Equal(t, 0, rs.Len())
+		assert.Equal(t, 0.0, rs.Mean())
+	})
+
+	t.Run("Recovers once NaN or Inf leave the window", func(t *testing.T) {
+		for _, bad := range []float64{math.NaN(), math.Inf(1)} {
+			rs := newRollingStats(3)
+			for _, value := range []float64{1, bad, 2, 3} {
+				rs.Add(value)
+			}
+			assert.True(t, rs.HasNonFinite())
+			assert.True(t, math.IsNaN(rs.Sum()))
+			assert.True(t, math.IsNaN(rs.Mean()))
+			assert.True(t, math.IsNaN(rs.Variance()))
+			assert.True(t, math.IsNaN(rs.SampleVariance()))
+
+			rs.Add(4)
+			assert.False(t, rs.HasNonFinite())
+			assert.InDelta(t, 9.0, rs.Sum(), 1e-12)
+			assert.InDelta(t, 2.0/3.0, rs.Variance(), 1e-12)
+		}
+	})
+
+	t.Run("Bounded error over a long random series", func(t *testing.T) {
+		const windowSize = 20
+		const n = 1000000
+
+		rng := rand.New(rand.NewSource(42))
+		sma := NewSMA(windowSize)
+		bb := NewBBands(windowSize, 2.0)
+		values := make([]float64, 0, n)
+
+		// Large offset with small noise is the worst case for naive running sums
+		price := 1e6
+		var maxMeanErr, maxStdErr float64
+		for i := 0; i < n; i++ {
+			price += rng.NormFloat64() * 0.01
+			values = append(values, price)
+			sma.AddValue(price)
+			bb.AddValue(price)
+
+			if i >= windowSize-1 && i%997 == 0 {
+				mean, stdDev := recomputeStats(values, windowSize)
+				smaValue, _ := sma.GetLastValue()
+				upper := bb.upperBands[len(bb.upperBands)-1]
+				middle := bb.middleBands[len(bb.middleBands)-1]
+
+				maxMeanErr = math.Max(maxMeanErr, math.Abs(smaValue-mean))
+				maxStdErr = math.Max(maxStdErr, math.Abs((upper-middle)/2.0-stdDev))
+			}
+		}
+
+		assert.Less(t, maxMeanErr, 1e-8)
+		assert.Less(t, maxStdErr, 1e-7)
+	})
+}
+
+func TestSortedWindow(t *testing.T) {
+	const windowSize = 6
+	sw := newSortedWindow(windowSize)
+	rng := rand.New(rand.NewSource(3))
+
+	values := make([]float64, 0)
+	for i := 0; i < 500; i++ {
+		value := float64(rng.Intn(10))
+		values = append(values, value)
+		sw.Add(value)
+
+		start := len(values) - windowSize
+		if start < 0 {
+			start = 0
+		}
+		expected := append([]float64(nil), values[start:]...)
+		sort.Float64s(expected)
+
+		assert.Equal(t, len(values) >= windowSize, sw.Full())
+		for j, v := range expected {
+			assert.Equal(t, v, sw.Sorted(j))
+		}
+		assert.Equal(t, sort.Search(len(expected), func(j int) bool { return expected[j] > 4 }), sw.CountLessOrEqual(4))
+	}
+
+	sw.Reset()
+	assert.False(t, sw.Full())
+	sw.Add(1)
+	assert.Equal(t, 1.0, sw.Sorted(0))
+
+	// NaN values are counted but not sorted, and evicted by position
+	sw = newSortedWindow(3)
+	for _, value := range []float64{1, math.NaN(), 2, 3} {
+		sw.Add(value)
+	}
+	assert.True(t, sw.HasNaN())
+	assert.Equal(t, 2, sw.CountLessOrEqual(3))
+	sw.Add(4)
+	assert.False(t, sw.HasNaN())
+	assert.Equal(t, []float64{2, 3, 4}, []float64{sw.Sorted(0), sw.Sorted(1), sw.Sorted(2)})
+	sw.Add(math.NaN())
+	sw.Reset()
+	assert.False(t, sw.HasNaN())
+}
@@ -0,0 +1,59 @@
+package indicators
+
+import (
+	"fmt"
+	"time"
+)
+
+// SessionPeriod is the length of the periods indicators such as pivot points
+// are computed over
+type SessionPeriod int
+
+const (
+	// SessionDaily starts a new period every day
+	SessionDaily SessionPeriod = iota
+	// SessionWeekly starts a new period every Monday
+	SessionWeekly
+	// SessionMonthly starts a new period on the first day of every month
+	SessionMonthly
+)
+
+// String returns the name of the session period
+func (p SessionPeriod) String() string {
+	switch p {
+	case SessionDaily:
+		return "daily"
+	case SessionWeekly:
+		return "weekly"
+	case SessionMonthly:
+		return "monthly"
+	default:
+		return fmt.Sprintf("SessionPeriod(%d)", int(p))
+	}
+}
+
+// sessionStart returns the start of the period containing t. Days start at
+// the given offset from midnight in location; the offset is applied to the
+// wall clock so that sessions keep their local opening time across daylight
+// saving changes.
+func sessionStart(t time.Time, location *time.Location, offset time.Duration, period SessionPeriod) time.Time {
+	local := t.In(location)
+	year, month, day := local.Date()
+
+	seconds := int(offset / time.Second)
+	nanos := int(offset % time.Second)
+	if local.Before(time.Date(year, month, day, 0, 0, seconds, nanos, location)) {
+		// Before the opening the time belongs to the session of the previous day
+		year, month, day = time.Date(year, month, day-1, 0, 0, 0, 0, location).Date()
+	}
+
+	switch period {
+	case SessionWeekly:
+		weekday := time.Date(year, month, day, 0, 0, 0, 0, location).Weekday()
+		day -= (int(weekday) + 6) % 7
+	case SessionMonthly:
+		day = 1
+	}
+
+	return time.Date(year, month, day, 0, 0, seconds, nanos, location)
+}
@@ -4,7 +4,7 @@ package indicators
 type SMA struct {
 	*BaseIndicator
 	windowSize int
-	valueSum   float64
+	stats      *rollingStats
 }
 
 // NewSMA creates a new Simple Moving Average indicator with specified window size
@@ -16,25 +16,17 @@ func NewSMA(windowSize int) *SMA {
 	return &SMA{
 		BaseIndicator: NewBaseIndicator("SMA"),
 		windowSize:    windowSize,
-		valueSum:      0.0,
+		stats:         newRollingStats(windowSize),
 	}
 }
 
 // AddValue adds a new value to the SMA calculation
 func (sma *SMA) AddValue(value float64) {
-	sma.input = append(sma.input, value)
-	sma.valueSum += value
+	sma.stats.Add(value)
 
 	// If we have enough values, calculate SMA
-	if len(sma.input) >= sma.windowSize {
-		// Calculate SMA
-		average := sma.valueSum / float64(sma.windowSize)
-		sma.AddOutput(average)
-
-		// Remove oldest value from sum
-		sma.valueSum -= sma.input[0]
-		// Remove oldest value from input
-		sma.input = sma.input[1:]
+	if sma.stats.Full() {
+		sma.AddOutput(sma.stats.Mean())
 	}
 }
 
@@ -42,3 +34,9 @@ func (sma *SMA) AddValue(value float64) {
 func (sma *SMA) GetWindowSize() int {
 	return sma.windowSize
 }
+
+// Reset clears all values in the SMA
+func (sma *SMA) Reset() {
+	sma.BaseIndicator.Reset()
+	sma.stats.Reset()
+}
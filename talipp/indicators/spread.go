@@ -0,0 +1,82 @@
+package indicators
+
+// Spread represents the spread of two series, first - hedgeRatio * second
+type Spread struct {
+	*BaseIndicator
+	hedgeRatio float64
+}
+
+// NewSpread creates a new spread indicator with specified hedge ratio (default 1.0)
+func NewSpread(hedgeRatio float64) *Spread {
+	return &Spread{
+		BaseIndicator: NewBaseIndicator("Spread"),
+		hedgeRatio:    hedgeRatio,
+	}
+}
+
+// AddPairValue adds the next value of both series to the spread calculation
+func (spread *Spread) AddPairValue(first, second float64) {
+	spread.AddOutput(first - spread.hedgeRatio*second)
+}
+
+// Ratio represents the ratio of two series, first / second
+type Ratio struct {
+	*BaseIndicator
+}
+
+// NewRatio creates a new ratio indicator
+func NewRatio() *Ratio {
+	return &Ratio{
+		BaseIndicator: NewBaseIndicator("Ratio"),
+	}
+}
+
+// AddPairValue adds the next value of both series to the ratio calculation
+func (ratio *Ratio) AddPairValue(first, second float64) {
+	var value float64 // 0 to avoid division by zero
+	if second != 0 {
+		value = first / second
+	}
+	ratio.AddOutput(value)
+}
+
+// SpreadZScore represents the rolling z-score of the spread of two series,
+// the usual entry and exit signal of pairs trading
+type SpreadZScore struct {
+	*BaseIndicator
+	windowSize int
+	hedgeRatio float64
+	zScore     *ZScore
+}
+
+// NewSpreadZScore creates a new spread z-score indicator
+// windowSize: the period for the mean and standard deviation of the spread (default 20)
+// hedgeRatio: the multiple of the second series subtracted from the first one (default 1.0)
+func NewSpreadZScore(windowSize int, hedgeRatio float64) *SpreadZScore {
+	return &SpreadZScore{
+		BaseIndicator: NewBaseIndicator("SpreadZScore"),
+		windowSize:    windowSize,
+		hedgeRatio:    hedgeRatio,
+		zScore:        NewZScore(windowSize),
+	}
+}
+
+// AddPairValue adds the next value of both series to the spread z-score calculation
+func (sz *SpreadZScore) AddPairValue(first, second float64) {
+	sz.zScore.AddValue(first - sz.hedgeRatio*second)
+	if sz.zScore.IsInitialized() {
+		value, _ := sz.zScore.GetLastValue()
+		sz.AddOutput(value)
+	}
+}
+
+// GetWindowSize returns the window size of the SpreadZScore
+func (sz *SpreadZScore) GetWindowSize() int {
+	return sz.windowSize
+}
+
+// Reset clears all values in the SpreadZScore
+func (sz *SpreadZScore) Reset() {
+	sz.BaseIndicator.Reset()
+	sz.zScore.Reset()
+}
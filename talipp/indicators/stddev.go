@@ -0,0 +1,66 @@
+package indicators
+
+import (
+	"math"
+)
+
+// StdDev represents a rolling standard deviation indicator
+type StdDev struct {
+	*BaseIndicator
+	windowSize int
+	sample     bool
+	stats      *rollingStats
+}
+
+// StdDevOption configures optional StdDev parameters
+type StdDevOption func(*StdDev)
+
+// WithStdDevSample makes the indicator use the sample (n-1) standard deviation
+// instead of the population one
+func WithStdDevSample() StdDevOption {
+	return func(sd *StdDev) {
+		sd.sample = true
+	}
+}
+
+// NewStdDev creates a new rolling standard deviation indicator with specified window size (default 20)
+func NewStdDev(windowSize int, opts ...StdDevOption) *StdDev {
+	if windowSize <= 0 {
+		panic("Window size must be greater than 0")
+	}
+
+	sd := &StdDev{
+		BaseIndicator: NewBaseIndicator("StdDev"),
+		windowSize:    windowSize,
+		stats:         newRollingStats(windowSize),
+	}
+	for _, opt := range opts {
+		opt(sd)
+	}
+
+	return sd
+}
+
+// AddValue adds a new value to the standard deviation calculation
+func (sd *StdDev) AddValue(value float64) {
+	sd.stats.Add(value)
+
+	if sd.stats.Full() {
+		if sd.sample {
+			sd.AddOutput(math.Sqrt(sd.stats.SampleVariance()))
+		} else {
+			sd.AddOutput(sd.stats.StdDev())
+		}
+	}
+}
+
+// GetWindowSize returns the window size of the StdDev
+func (sd *StdDev) GetWindowSize() int {
+	return sd.windowSize
+}
+
+// Reset clears all values in the StdDev
+func (sd *StdDev) Reset() {
+	sd.BaseIndicator.Reset()
+	sd.stats.Reset()
+}
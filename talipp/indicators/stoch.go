@@ -1,20 +1,17 @@
 package indicators
 
-import (
-	"math"
-)
-
 // Stoch represents a Stochastic Oscillator indicator
 type Stoch struct {
 	*BaseIndicator
-	windowSize      int
-	smoothK         int
-	smoothD         int
-	highValues      []float64
-	lowValues       []float64
-	closeValues     []float64
-	kValues         []float64
-	dValues         []float64
+	windowSize int
+	smoothK    int
+	smoothD    int
+	maType     MAType
+	kMA        MovingAverage
+	dMA        MovingAverage
+	extremes   *rollingExtremes
+	kValues    []float64
+	dValues    []float64
 }
 
 // StochOutput represents the output of Stochastic Oscillator calculations
@@ -23,26 +20,44 @@ type StochOutput struct {
 	D float64
 }
 
+// StochOption configures optional Stochastic Oscillator parameters
+type StochOption func(*Stoch)
+
+// WithStochMAType sets the moving average used for %K smoothing and %D
+// (default MATypeSMA)
+func WithStochMAType(maType MAType) StochOption {
+	return func(stoch *Stoch) {
+		stoch.maType = maType
+	}
+}
+
 // NewStoch creates a new Stochastic Oscillator indicator
 // windowSize: the period for the %K calculation (default 14)
 // smoothK: the period for %K smoothing (default 1 - no smoothing)
 // smoothD: the period for %D calculation (default 3)
-func NewStoch(windowSize, smoothK, smoothD int) *Stoch {
+func NewStoch(windowSize, smoothK, smoothD int, opts ...StochOption) *Stoch {
 	if windowSize <= 0 || smoothK <= 0 || smoothD <= 0 {
 		panic("All periods must be greater than 0")
 	}
 
-	return &Stoch{
+	stoch := &Stoch{
 		BaseIndicator: NewBaseIndicator("Stoch"),
 		windowSize:    windowSize,
 		smoothK:       smoothK,
 		smoothD:       smoothD,
-		highValues:    make([]float64, 0),
-		lowValues:     make([]float64, 0),
-		closeValues:   make([]float64, 0),
+		maType:        MATypeSMA,
+		extremes:      newRollingExtremes(windowSize),
 		kValues:       make([]float64, 0),
 		dValues:       make([]float64, 0),
 	}
+	for _, opt := range opts {
+		opt(stoch)
+	}
+	// A moving average of window 1 passes values through unchanged
+	stoch.kMA = NewMovingAverage(stoch.maType, smoothK)
+	stoch.dMA = NewMovingAverage(stoch.maType, smoothD)
+
+	return stoch
 }
 
 // AddValue is not the preferred method for Stochastic, but included for interface compatibility
@@ -52,28 +67,14 @@ func (stoch *Stoch) AddValue(value float64) {
 
 // AddHLCValue adds a new high, low, close data to the Stochastic Oscillator calculation
 func (stoch *Stoch) AddHLCValue(high, low, close float64) {
-	stoch.highValues = append(stoch.highValues, high)
-	stoch.lowValues = append(stoch.lowValues, low)
-	stoch.closeValues = append(stoch.closeValues, close)
-	
-	// Keep only the windowSize values
-	if len(stoch.highValues) > stoch.windowSize {
-		stoch.highValues = stoch.highValues[1:]
-		stoch.lowValues = stoch.lowValues[1:]
-		stoch.closeValues = stoch.closeValues[1:]
-	}
-	
+	stoch.extremes.Add(high, low)
+
 	// If we have enough values, calculate %K
-	if len(stoch.closeValues) == stoch.windowSize {
-		// Find highest high and lowest low in the window
-		highestHigh := stoch.highValues[0]
-		lowestLow := stoch.lowValues[0]
-		
-		for i := 1; i < stoch.windowSize; i++ {
-			highestHigh = math.Max(highestHigh, stoch.highValues[i])
-			lowestLow = math.Min(lowestLow, stoch.lowValues[i])
-		}
-		
+	if stoch.extremes.Full() {
+		// Highest high and lowest low in the window
+		highestHigh := stoch.extremes.Max()
+		lowestLow := stoch.extremes.Min()
+
 		// Calculate raw %K
 		var kValue float64
 		if highestHigh == lowestLow {
@@ -81,45 +82,22 @@ func (stoch *Stoch) AddHLCValue(high, low, close float64) {
 		} else {
 			kValue = 100.0 * ((close - lowestLow) / (highestHigh - lowestLow))
 		}
-		
-		// Add K value for smoothing
-		stoch.kValues = append(stoch.kValues, kValue)
-		
-		// Apply smoothing to %K if required
-		if stoch.smoothK > 1 {
-			if len(stoch.kValues) >= stoch.smoothK {
-				var sum float64
-				for i := len(stoch.kValues) - stoch.smoothK; i < len(stoch.kValues); i++ {
-					sum += stoch.kValues[i]
-				}
-				kValue = sum / float64(stoch.smoothK)
-				
-				// Keep only the needed K values
-				if len(stoch.kValues) > stoch.smoothK {
-					stoch.kValues = stoch.kValues[1:]
-				}
-			} else {
-				// Not enough data for K smoothing yet
-				return
-			}
+
+		// Apply smoothing to %K
+		stoch.kMA.AddValue(kValue)
+		if !stoch.kMA.IsInitialized() {
+			// Not enough data for K smoothing yet
+			return
 		}
-		
-		// Calculate %D (SMA of %K)
-		stoch.dValues = append(stoch.dValues, kValue)
-		
-		if len(stoch.dValues) >= stoch.smoothD {
-			var sum float64
-			for i := len(stoch.dValues) - stoch.smoothD; i < len(stoch.dValues); i++ {
-				sum += stoch.dValues[i]
-			}
-			dValue := sum / float64(stoch.smoothD)
-			
-			// Keep only the needed D values
-			if len(stoch.dValues) > stoch.smoothD {
-				stoch.dValues = stoch.dValues[1:]
-			}
-			
-			
+		kValue, _ = stoch.kMA.GetLastValue()
+		stoch.kValues = append(stoch.kValues, kValue)
+
+		// Calculate %D (moving average of %K)
+		stoch.dMA.AddValue(kValue)
+		if stoch.dMA.IsInitialized() {
+			dValue, _ := stoch.dMA.GetLastValue()
+			stoch.dValues = append(stoch.dValues, dValue)
+
 			// Use K as the main indicator output
 			stoch.AddOutput(kValue)
 			stoch.AddOutput(dValue)
@@ -132,25 +110,35 @@ func (stoch *Stoch) GetWindowSize() int {
 	return stoch.windowSize
 }
 
+// Reset clears all values in the Stochastic Oscillator
+func (stoch *Stoch) Reset() {
+	stoch.BaseIndicator.Reset()
+	stoch.kMA.Reset()
+	stoch.dMA.Reset()
+	stoch.extremes.Reset()
+	stoch.kValues = make([]float64, 0)
+	stoch.dValues = make([]float64, 0)
+}
+
 // GetStochOutput returns the complete Stochastic Oscillator output (K, D)
 func (stoch *Stoch) GetStochOutput() []StochOutput {
 	// Find the minimum length between K and D values
 	kLen := len(stoch.kValues)
 	dLen := len(stoch.dValues)
-	
+
 	// Get the minimum of the two
 	resultLen := kLen
 	if dLen < resultLen {
 		resultLen = dLen
 	}
-	
+
 	// Start from the appropriate positions
 	kStart := kLen - resultLen
 	dStart := dLen - resultLen
-	
+
 	// Create result slice
 	results := make([]StochOutput, resultLen)
-	
+
 	// Fill in the results
 	for i := 0; i < resultLen; i++ {
 		results[i] = StochOutput{
@@ -158,7 +146,7 @@ func (stoch *Stoch) GetStochOutput() []StochOutput {
 			D: stoch.dValues[dStart+i],
 		}
 	}
-	
+
 	return results
 }
 
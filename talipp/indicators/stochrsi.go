@@ -0,0 +1,71 @@
+package indicators
+
+// StochRSI represents the Stochastic RSI indicator, the Stochastic Oscillator
+// applied to RSI values
+type StochRSI struct {
+	*BaseIndicator
+	rsi   *RSI
+	stoch *Stoch
+}
+
+// NewStochRSI creates a new Stochastic RSI indicator
+// rsiWindowSize: the period for the RSI (default 14)
+// stochWindowSize: the period for the stochastic of the RSI (default 14)
+// smoothK: the period for %K smoothing (default 3)
+// smoothD: the period for %D calculation (default 3)
+func NewStochRSI(rsiWindowSize, stochWindowSize, smoothK, smoothD int, opts ...StochOption) *StochRSI {
+	if rsiWindowSize <= 0 {
+		panic("All periods must be greater than 0")
+	}
+
+	return &StochRSI{
+		BaseIndicator: NewBaseIndicator("StochRSI"),
+		rsi:           NewRSI(rsiWindowSize),
+		stoch:         NewStoch(stochWindowSize, smoothK, smoothD, opts...),
+	}
+}
+
+// AddValue adds a new value to the Stochastic RSI calculation
+func (srsi *StochRSI) AddValue(value float64) {
+	srsi.rsi.AddValue(value)
+	if !srsi.rsi.IsInitialized() {
+		return
+	}
+
+	// The RSI is used as high, low and close of the stochastic
+	rsiValue, _ := srsi.rsi.GetLastValue()
+	outputs := len(srsi.stoch.dValues)
+	srsi.stoch.AddHLCValue(rsiValue, rsiValue, rsiValue)
+
+	// The base indicator output is %K, once %D is available as well
+	if len(srsi.stoch.dValues) > outputs {
+		srsi.AddOutput(srsi.stoch.kValues[len(srsi.stoch.kValues)-1])
+	}
+}
+
+// GetWindowSize returns the stochastic window size of the Stochastic RSI
+func (srsi *StochRSI) GetWindowSize() int {
+	return srsi.stoch.GetWindowSize()
+}
+
+// GetStochOutput returns the complete Stochastic RSI output (K, D)
+func (srsi *StochRSI) GetStochOutput() []StochOutput {
+	return srsi.stoch.GetStochOutput()
+}
+
+// GetKValues returns just the %K values
+func (srsi *StochRSI) GetKValues() []float64 {
+	return srsi.stoch.GetKValues()
+}
+
+// GetDValues returns just the %D values
+func (srsi *StochRSI) GetDValues() []float64 {
+	return srsi.stoch.GetDValues()
+}
+
+// Reset clears all values in the Stochastic RSI
+func (srsi *StochRSI) Reset() {
+	srsi.BaseIndicator.Reset()
+	srsi.rsi.Reset()
+	srsi.stoch.Reset()
+}
@@ -0,0 +1,116 @@
+package indicators
+
+// SuperTrend represents the SuperTrend indicator, an ATR based trailing stop
+type SuperTrend struct {
+	*BaseIndicator
+	atrPeriod  int
+	multiplier float64
+	atr        *ATR
+	prevClose  float64
+	upperBand  float64
+	lowerBand  float64
+	trend      TrendDirection
+	results    []TrailingStopOutput
+}
+
+// NewSuperTrend creates a new SuperTrend indicator
+// atrPeriod: the period for the ATR (default 10)
+// multiplier: the ATR multiple for the bands (default 3.0)
+func NewSuperTrend(atrPeriod int, multiplier float64, opts ...ATROption) *SuperTrend {
+	if atrPeriod <= 0 {
+		panic("Window size must be greater than 0")
+	}
+
+	return &SuperTrend{
+		BaseIndicator: NewBaseIndicator("SuperTrend"),
+		atrPeriod:     atrPeriod,
+		multiplier:    multiplier,
+		atr:           NewATR(atrPeriod, opts...),
+		results:       make([]TrailingStopOutput, 0),
+	}
+}
+
+// AddOHLCValue adds a new OHLC candle data to the SuperTrend calculation
+func (st *SuperTrend) AddOHLCValue(high, low, close float64) {
+	st.atr.AddOHLCValue(high, low, close)
+	if !st.atr.IsInitialized() {
+		st.prevClose = close
+		return
+	}
+
+	atrValue, _ := st.atr.GetLastValue()
+	hl2 := (high + low) / 2.0
+	upperBand := hl2 + st.multiplier*atrValue
+	lowerBand := hl2 - st.multiplier*atrValue
+
+	if len(st.results) == 0 {
+		// Start on the short side, like TradingView
+		st.upperBand = upperBand
+		st.lowerBand = lowerBand
+		st.trend = TrendDown
+		st.addResult(false)
+		st.prevClose = close
+		return
+	}
+
+	// The bands only move in the direction of the trend unless price closed beyond them
+	if upperBand < st.upperBand || st.prevClose > st.upperBand {
+		st.upperBand = upperBand
+	}
+	if lowerBand > st.lowerBand || st.prevClose < st.lowerBand {
+		st.lowerBand = lowerBand
+	}
+
+	prevTrend := st.trend
+	if st.trend == TrendDown && close > st.upperBand {
+		st.trend = TrendUp
+	} else if st.trend == TrendUp && close < st.lowerBand {
+		st.trend = TrendDown
+	}
+	st.addResult(st.trend != prevTrend)
+	st.prevClose = close
+}
+
+// addResult stores the stop of the current candle
+func (st *SuperTrend) addResult(flipped bool) {
+	stop := st.upperBand
+	if st.trend == TrendUp {
+		stop = st.lowerBand
+	}
+
+	st.results = append(st.results, TrailingStopOutput{
+		Stop:    stop,
+		Trend:   st.trend,
+		Flipped: flipped,
+	})
+	st.AddOutput(stop)
+}
+
+// AddValue is not the preferred method for SuperTrend, but can be used for compatibility
+// with the Indicator interface. It will use the value as high, low and close.
+func (st *SuperTrend) AddValue(value float64) {
+	st.AddOHLCValue(value, value, value)
+}
+
+// GetWindowSize returns the ATR period of the SuperTrend
+func (st *SuperTrend) GetWindowSize() int {
+	return st.atrPeriod
+}
+
+// Reset clears all values in the SuperTrend
+func (st *SuperTrend) Reset() {
+	st.BaseIndicator.Reset()
+	st.atr.Reset()
+	st.prevClose = 0
+	st.upperBand = 0
+	st.lowerBand = 0
+	st.trend = 0
+	st.results = make([]TrailingStopOutput, 0)
+}
+
+// GetSuperTrendOutput returns the stop level, trend direction and flip flag for every candle
+func (st *SuperTrend) GetSuperTrendOutput() []TrailingStopOutput {
+	result := make([]TrailingStopOutput, len(st.results))
+	copy(result, st.results)
+	return result
+}
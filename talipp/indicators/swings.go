@@ -0,0 +1,88 @@
+package indicators
+
+import (
+	"fmt"
+	"time"
+)
+
+// SwingKind tells whether a swing point is a high or a low
+type SwingKind int
+
+const (
+	// SwingLow is a local minimum of the lows
+	SwingLow SwingKind = -1
+	// SwingHigh is a local maximum of the highs
+	SwingHigh SwingKind = 1
+)
+
+// String returns the name of the swing kind
+func (k SwingKind) String() string {
+	switch k {
+	case SwingLow:
+		return "low"
+	case SwingHigh:
+		return "high"
+	default:
+		return fmt.Sprintf("SwingKind(%d)", int(k))
+	}
+}
+
+// SwingPoint represents a swing high or low.
+//
+// Swings are only known some bars after they happened. Index and Timestamp
+// identify the bar the swing belongs to, while ConfirmedIndex is the bar on
+// which it was confirmed; confirmed swings never change. Tentative swings are
+// candidates that may still move to a later bar or disappear, and have a
+// ConfirmedIndex of -1.
+type SwingPoint struct {
+	Kind  SwingKind
+	Price float64
+	// Index is the index of the swing bar, counting from 0 in the order bars
+	// were added to the indicator
+	Index int
+	// Timestamp is the timestamp of the swing bar, zero for bars added
+	// without a candle
+	Timestamp time.Time
+	// ConfirmedIndex is the index of the bar that confirmed the swing, or -1
+	// for tentative swings
+	ConfirmedIndex int
+}
+
+// swings holds the confirmed swing points shared by the swing detectors
+type swings struct {
+	bars      int
+	confirmed []SwingPoint
+	onSwing   func(SwingPoint)
+}
+
+// newSwings creates a new swings with no swing points
+func newSwings() swings {
+	return swings{confirmed: make([]SwingPoint, 0)}
+}
+
+// confirm records a swing point as confirmed on the current bar
+func (s *swings) confirm(point SwingPoint) {
+	point.ConfirmedIndex = s.bars - 1
+	s.confirmed = append(s.confirmed, point)
+	if s.onSwing != nil {
+		s.onSwing(point)
+	}
+}
+
+// OnSwing sets a callback to be called with every newly confirmed swing point
+func (s *swings) OnSwing(callback func(SwingPoint)) {
+	s.onSwing = callback
+}
+
+// GetSwings returns all confirmed swing points in order of confirmation
+func (s *swings) GetSwings() []SwingPoint {
+	result := make([]SwingPoint, len(s.confirmed))
+	copy(result, s.confirmed)
+	return result
+}
+
+// resetSwings clears the swing points
+func (s *swings) resetSwings() {
+	s.bars = 0
+	s.confirmed = make([]SwingPoint, 0)
+}
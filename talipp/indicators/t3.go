@@ -0,0 +1,64 @@
+package indicators
+
+// T3 represents Tillson's T3 Moving Average indicator, a six-fold EMA
+// combined with the volume factor into a generalized DEMA
+type T3 struct {
+	*BaseIndicator
+	windowSize int
+	emas       []ValueIndicator
+	c1         float64
+	c2         float64
+	c3         float64
+	c4         float64
+}
+
+// NewT3 creates a new T3 Moving Average indicator
+// windowSize: the period of each EMA (default 5)
+// vFactor: the volume factor (default 0.7)
+func NewT3(windowSize int, vFactor float64) *T3 {
+	if windowSize <= 0 {
+		panic("Window size must be greater than 0")
+	}
+
+	emas := make([]ValueIndicator, 6)
+	for i := range emas {
+		emas[i] = NewEMA(windowSize)
+	}
+
+	a := vFactor
+	return &T3{
+		BaseIndicator: NewBaseIndicator("T3"),
+		windowSize:    windowSize,
+		emas:          emas,
+		c1:            -a * a * a,
+		c2:            3*a*a + 3*a*a*a,
+		c3:            -6*a*a - 3*a - 3*a*a*a,
+		c4:            1 + 3*a + a*a*a + 3*a*a,
+	}
+}
+
+// AddValue adds a new value to the T3 calculation
+func (t3 *T3) AddValue(value float64) {
+	if !feedChain(value, t3.emas...) {
+		return
+	}
+
+	e3, _ := t3.emas[2].GetLastValue()
+	e4, _ := t3.emas[3].GetLastValue()
+	e5, _ := t3.emas[4].GetLastValue()
+	e6, _ := t3.emas[5].GetLastValue()
+	t3.AddOutput(t3.c1*e6 + t3.c2*e5 + t3.c3*e4 + t3.c4*e3)
+}
+
+// GetWindowSize returns the window size of the T3
+func (t3 *T3) GetWindowSize() int {
+	return t3.windowSize
+}
+
+// Reset clears all values in the T3
+func (t3 *T3) Reset() {
+	t3.BaseIndicator.Reset()
+	for _, ema := range t3.emas {
+		ema.Reset()
+	}
+}
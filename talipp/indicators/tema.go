@@ -0,0 +1,51 @@
+package indicators
+
+// TEMA represents a Triple Exponential Moving Average indicator
+type TEMA struct {
+	*BaseIndicator
+	windowSize int
+	ema1       *EMA
+	ema2       *EMA
+	ema3       *EMA
+}
+
+// NewTEMA creates a new Triple Exponential Moving Average indicator with specified window size
+func NewTEMA(windowSize int) *TEMA {
+	if windowSize <= 0 {
+		panic("Window size must be greater than 0")
+	}
+
+	return &TEMA{
+		BaseIndicator: NewBaseIndicator("TEMA"),
+		windowSize:    windowSize,
+		ema1:          NewEMA(windowSize),
+		ema2:          NewEMA(windowSize),
+		ema3:          NewEMA(windowSize),
+	}
+}
+
+// AddValue adds a new value to the TEMA calculation
+func (tema *TEMA) AddValue(value float64) {
+	if !feedChain(value, tema.ema1, tema.ema2, tema.ema3) {
+		return
+	}
+
+	// TEMA = 3 * EMA - 3 * EMA(EMA) + EMA(EMA(EMA))
+	ema1Value, _ := tema.ema1.GetLastValue()
+	ema2Value, _ := tema.ema2.GetLastValue()
+	ema3Value, _ := tema.ema3.GetLastValue()
+	tema.AddOutput(3.0*ema1Value - 3.0*ema2Value + ema3Value)
+}
+
+// GetWindowSize returns the window size of the TEMA
+func (tema *TEMA) GetWindowSize() int {
+	return tema.windowSize
+}
+
+// Reset clears all values in the TEMA
+func (tema *TEMA) Reset() {
+	tema.BaseIndicator.Reset()
+	tema.ema1.Reset()
+	tema.ema2.Reset()
+	tema.ema3.Reset()
+}
@@ -0,0 +1,43 @@
+package indicators
+
+// TRIX represents the TRIX indicator, the one period rate of change of a
+// triple smoothed EMA
+type TRIX struct {
+	*BaseIndicator
+	windowSize int
+	stages     []ValueIndicator
+}
+
+// NewTRIX creates a new TRIX indicator with specified window size (default 18)
+func NewTRIX(windowSize int) *TRIX {
+	if windowSize <= 0 {
+		panic("Window size must be greater than 0")
+	}
+
+	return &TRIX{
+		BaseIndicator: NewBaseIndicator("TRIX"),
+		windowSize:    windowSize,
+		stages:        []ValueIndicator{NewEMA(windowSize), NewEMA(windowSize), NewEMA(windowSize), NewROC(1)},
+	}
+}
+
+// AddValue adds a new value to the TRIX calculation
+func (trix *TRIX) AddValue(value float64) {
+	if feedChain(value, trix.stages...) {
+		rocValue, _ := trix.stages[len(trix.stages)-1].GetLastValue()
+		trix.AddOutput(rocValue)
+	}
+}
+
+// GetWindowSize returns the window size of the TRIX
+func (trix *TRIX) GetWindowSize() int {
+	return trix.windowSize
+}
+
+// Reset clears all values in the TRIX
+func (trix *TRIX) Reset() {
+	trix.BaseIndicator.Reset()
+	for _, stage := range trix.stages {
+		stage.Reset()
+	}
+}
@@ -0,0 +1,68 @@
+package indicators
+
+import (
+	"math"
+)
+
+// TSI represents the True Strength Index indicator, the ratio of the double
+// smoothed momentum to the double smoothed absolute momentum
+type TSI struct {
+	*BaseIndicator
+	prevValue     float64
+	firstValueSet bool
+	momentum      []ValueIndicator
+	absMomentum   []ValueIndicator
+}
+
+// NewTSI creates a new True Strength Index indicator
+// longLength: the period for the first smoothing (default 25)
+// shortLength: the period for the second smoothing (default 13)
+func NewTSI(longLength, shortLength int) *TSI {
+	if longLength <= 0 || shortLength <= 0 {
+		panic("All periods must be greater than 0")
+	}
+
+	return &TSI{
+		BaseIndicator: NewBaseIndicator("TSI"),
+		momentum:      []ValueIndicator{NewEMA(longLength), NewEMA(shortLength)},
+		absMomentum:   []ValueIndicator{NewEMA(longLength), NewEMA(shortLength)},
+	}
+}
+
+// AddValue adds a new value to the TSI calculation
+func (tsi *TSI) AddValue(value float64) {
+	if !tsi.firstValueSet {
+		tsi.prevValue = value
+		tsi.firstValueSet = true
+		return
+	}
+
+	change := value - tsi.prevValue
+	tsi.prevValue = value
+
+	ready := feedChain(change, tsi.momentum...)
+	feedChain(math.Abs(change), tsi.absMomentum...)
+	if !ready {
+		return
+	}
+
+	// TSI = 100 * EMA(EMA(change)) / EMA(EMA(|change|))
+	smoothed, _ := tsi.momentum[1].GetLastValue()
+	absSmoothed, _ := tsi.absMomentum[1].GetLastValue()
+	var tsiValue float64
+	if absSmoothed != 0 {
+		tsiValue = 100.0 * smoothed / absSmoothed
+	}
+	tsi.AddOutput(tsiValue)
+}
+
+// Reset clears all values in the TSI
+func (tsi *TSI) Reset() {
+	tsi.BaseIndicator.Reset()
+	tsi.prevValue = 0
+	tsi.firstValueSet = false
+	for i := range tsi.momentum {
+		tsi.momentum[i].Reset()
+		tsi.absMomentum[i].Reset()
+	}
+}
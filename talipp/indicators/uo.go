@@ -0,0 +1,84 @@
+package indicators
+
+import (
+	"math"
+)
+
+// UltimateOsc represents the Ultimate Oscillator indicator, a weighted
+// average of buying pressure over three windows
+type UltimateOsc struct {
+	*BaseIndicator
+	prevClose     float64
+	firstValueSet bool
+	pressure      [3]*rollingStats
+	trueRange     [3]*rollingStats
+}
+
+// NewUltimateOsc creates a new Ultimate Oscillator indicator
+// fastLength: the short period (default 7)
+// midLength: the medium period (default 14)
+// slowLength: the long period (default 28)
+func NewUltimateOsc(fastLength, midLength, slowLength int) *UltimateOsc {
+	if fastLength <= 0 || midLength <= 0 || slowLength <= 0 {
+		panic("All periods must be greater than 0")
+	}
+
+	uo := &UltimateOsc{
+		BaseIndicator: NewBaseIndicator("UltimateOsc"),
+	}
+	for i, length := range []int{fastLength, midLength, slowLength} {
+		uo.pressure[i] = newRollingStats(length)
+		uo.trueRange[i] = newRollingStats(length)
+	}
+
+	return uo
+}
+
+// AddOHLCValue adds a new OHLC candle data to the Ultimate Oscillator calculation
+func (uo *UltimateOsc) AddOHLCValue(high, low, close float64) {
+	if !uo.firstValueSet {
+		uo.prevClose = close
+		uo.firstValueSet = true
+		return
+	}
+
+	// Buying pressure = close - true low, true range = true high - true low
+	trueLow := math.Min(low, uo.prevClose)
+	trueHigh := math.Max(high, uo.prevClose)
+	uo.prevClose = close
+
+	for i := range uo.pressure {
+		uo.pressure[i].Add(close - trueLow)
+		uo.trueRange[i].Add(trueHigh - trueLow)
+	}
+
+	if !uo.pressure[0].Full() || !uo.pressure[1].Full() || !uo.pressure[2].Full() {
+		return
+	}
+
+	// UO = 100 * (4 * fast average + 2 * medium average + slow average) / 7
+	var averages [3]float64
+	for i := range averages {
+		if trueRangeSum := uo.trueRange[i].Sum(); trueRangeSum != 0 {
+			averages[i] = uo.pressure[i].Sum() / trueRangeSum
+		}
+	}
+	uo.AddOutput(100.0 * (4.0*averages[0] + 2.0*averages[1] + averages[2]) / 7.0)
+}
+
+// AddValue is not the preferred method for the Ultimate Oscillator, but can be used for
+// compatibility with the Indicator interface. It will use the value as high, low and close.
+func (uo *UltimateOsc) AddValue(value float64) {
+	uo.AddOHLCValue(value, value, value)
+}
+
+// Reset clears all values in the Ultimate Oscillator
+func (uo *UltimateOsc) Reset() {
+	uo.BaseIndicator.Reset()
+	uo.prevClose = 0
+	uo.firstValueSet = false
+	for i := range uo.pressure {
+		uo.pressure[i].Reset()
+		uo.trueRange[i].Reset()
+	}
+}
@@ -0,0 +1,62 @@
+package indicators
+
+// Variance represents a rolling variance indicator
+type Variance struct {
+	*BaseIndicator
+	windowSize int
+	sample     bool
+	stats      *rollingStats
+}
+
+// VarianceOption configures optional Variance parameters
+type VarianceOption func(*Variance)
+
+// WithVarianceSample makes the indicator use the sample (n-1) variance
+// instead of the population one
+func WithVarianceSample() VarianceOption {
+	return func(variance *Variance) {
+		variance.sample = true
+	}
+}
+
+// NewVariance creates a new rolling variance indicator with specified window size (default 20)
+func NewVariance(windowSize int, opts ...VarianceOption) *Variance {
+	if windowSize <= 0 {
+		panic("Window size must be greater than 0")
+	}
+
+	variance := &Variance{
+		BaseIndicator: NewBaseIndicator("Variance"),
+		windowSize:    windowSize,
+		stats:         newRollingStats(windowSize),
+	}
+	for _, opt := range opts {
+		opt(variance)
+	}
+
+	return variance
+}
+
+// AddValue adds a new value to the variance calculation
+func (variance *Variance) AddValue(value float64) {
+	variance.stats.Add(value)
+
+	if variance.stats.Full() {
+		if variance.sample {
+			variance.AddOutput(variance.stats.SampleVariance())
+		} else {
+			variance.AddOutput(variance.stats.Variance())
+		}
+	}
+}
+
+// GetWindowSize returns the window size of the Variance
+func (variance *Variance) GetWindowSize() int {
+	return variance.windowSize
+}
+
+// Reset clears all values in the Variance
+func (variance *Variance) Reset() {
+	variance.BaseIndicator.Reset()
+	variance.stats.Reset()
+}
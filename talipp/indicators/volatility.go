@@ -0,0 +1,219 @@
+package indicators
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/revanthstrakz/gotalipp/talipp/ohlcv"
+)
+
+// VolatilityEstimator identifies a historical volatility estimator
+type VolatilityEstimator int
+
+const (
+	// VolatilityCloseToClose selects the standard deviation of log close-to-close returns
+	VolatilityCloseToClose VolatilityEstimator = iota
+	// VolatilityParkinson selects the Parkinson high-low range estimator
+	VolatilityParkinson
+	// VolatilityGarmanKlass selects the Garman-Klass OHLC estimator
+	VolatilityGarmanKlass
+	// VolatilityRogersSatchell selects the drift independent Rogers-Satchell OHLC estimator
+	VolatilityRogersSatchell
+	// VolatilityYangZhang selects the Yang-Zhang estimator, which also accounts
+	// for the gaps between the previous close and the open
+	VolatilityYangZhang
+)
+
+// String returns the name of the volatility estimator
+func (e VolatilityEstimator) String() string {
+	switch e {
+	case VolatilityCloseToClose:
+		return "CloseToClose"
+	case VolatilityParkinson:
+		return "Parkinson"
+	case VolatilityGarmanKlass:
+		return "GarmanKlass"
+	case VolatilityRogersSatchell:
+		return "RogersSatchell"
+	case VolatilityYangZhang:
+		return "YangZhang"
+	default:
+		return fmt.Sprintf("VolatilityEstimator(%d)", int(e))
+	}
+}
+
+// TradingDaysPerYear is the usual number of trading days of equity markets
+const TradingDaysPerYear = 252
+
+// PeriodsPerYear returns the number of bars of the given interval in a year,
+// the annualisation factor for the volatility of such bars.
+// barInterval: the duration of one bar
+// sessionLength: how long the market trades each day, e.g. 6h30m for US
+// equities or 24h for crypto currencies
+// tradingDays: the number of trading days in a year, e.g. TradingDaysPerYear or 365
+func PeriodsPerYear(barInterval, sessionLength time.Duration, tradingDays float64) float64 {
+	if barInterval <= 0 || sessionLength <= 0 {
+		panic("Bar interval and session length must be greater than 0")
+	}
+
+	// Intraday bars split each session
+	if barInterval < sessionLength {
+		return tradingDays * float64(sessionLength) / float64(barInterval)
+	}
+
+	// Longer bars span calendar days, of which a year has 365, but no bar
+	// covers less than one trading day, so daily bars give tradingDays
+	days := float64(barInterval) / float64(24*time.Hour)
+	return math.Min(tradingDays, 365/days)
+}
+
+// HistoricalVolatility represents the realised volatility of a window of
+// candles, annualised with the number of bars per year. The output is a
+// fraction, 0.2 meaning 20% annualised volatility.
+type HistoricalVolatility struct {
+	*BaseIndicator
+	estimator      VolatilityEstimator
+	windowSize     int
+	periodsPerYear float64
+	prevClose      float64
+	firstValueSet  bool
+	// terms holds the per-bar terms of the estimator: log returns for
+	// close-to-close and the Rogers-Satchell terms for Yang-Zhang
+	terms *rollingStats
+	// overnight and openClose hold the log(open / previous close) and
+	// log(close / open) returns used by Yang-Zhang
+	overnight *rollingStats
+	openClose *rollingStats
+}
+
+// VolatilityOption configures optional HistoricalVolatility parameters
+type VolatilityOption func(*HistoricalVolatility)
+
+// WithVolatilityPeriodsPerYear sets the annualisation factor, the number of
+// bars in a year (default TradingDaysPerYear, for daily bars). Use
+// PeriodsPerYear to derive it from the bar interval, or 1 to get the
+// volatility per bar.
+func WithVolatilityPeriodsPerYear(periodsPerYear float64) VolatilityOption {
+	return func(hv *HistoricalVolatility) {
+		hv.periodsPerYear = periodsPerYear
+	}
+}
+
+// NewHistoricalVolatility creates a new historical volatility indicator
+// estimator: the volatility estimator to use
+// windowSize: the number of bars in the estimation window (default 20)
+func NewHistoricalVolatility(estimator VolatilityEstimator, windowSize int, opts ...VolatilityOption) *HistoricalVolatility {
+	if windowSize <= 1 {
+		panic("Window size must be greater than 1")
+	}
+
+	switch estimator {
+	case VolatilityCloseToClose, VolatilityParkinson, VolatilityGarmanKlass, VolatilityRogersSatchell, VolatilityYangZhang:
+	default:
+		panic(fmt.Sprintf("Unknown volatility estimator: %s", estimator))
+	}
+
+	hv := &HistoricalVolatility{
+		BaseIndicator:  NewBaseIndicator(estimator.String() + "Volatility"),
+		estimator:      estimator,
+		windowSize:     windowSize,
+		periodsPerYear: TradingDaysPerYear,
+		terms:          newRollingStats(windowSize),
+		overnight:      newRollingStats(windowSize),
+		openClose:      newRollingStats(windowSize),
+	}
+	for _, opt := range opts {
+		opt(hv)
+	}
+
+	return hv
+}
+
+// AddCandle adds a new candle to the volatility calculation
+func (hv *HistoricalVolatility) AddCandle(candle *ohlcv.OHLCV) {
+	hv.AddOpenHLCValue(candle.Open, candle.High, candle.Low, candle.Close)
+}
+
+// AddOpenHLCValue adds a new open, high, low and close to the volatility calculation
+func (hv *HistoricalVolatility) AddOpenHLCValue(open, high, low, close float64) {
+	prevClose := hv.prevClose
+	hasPrevClose := hv.firstValueSet
+	hv.prevClose = close
+	hv.firstValueSet = true
+
+	logHL := math.Log(high / low)
+	logCO := math.Log(close / open)
+	rogersSatchell := math.Log(high/close)*math.Log(high/open) + math.Log(low/close)*math.Log(low/open)
+
+	var variance float64
+	switch hv.estimator {
+	case VolatilityCloseToClose:
+		if !hasPrevClose {
+			return
+		}
+		hv.terms.Add(math.Log(close / prevClose))
+		variance = hv.terms.SampleVariance()
+
+	case VolatilityParkinson:
+		hv.terms.Add(logHL * logHL)
+		variance = hv.terms.Mean() / (4 * math.Ln2)
+
+	case VolatilityGarmanKlass:
+		hv.terms.Add(0.5*logHL*logHL - (2*math.Ln2-1)*logCO*logCO)
+		variance = hv.terms.Mean()
+
+	case VolatilityRogersSatchell:
+		hv.terms.Add(rogersSatchell)
+		variance = hv.terms.Mean()
+
+	case VolatilityYangZhang:
+		if !hasPrevClose {
+			return
+		}
+		hv.overnight.Add(math.Log(open / prevClose))
+		hv.openClose.Add(logCO)
+		hv.terms.Add(rogersSatchell)
+
+		// Weight minimising the variance of the estimator
+		n := float64(hv.windowSize)
+		k := 0.34 / (1.34 + (n+1)/(n-1))
+		variance = hv.overnight.SampleVariance() + k*hv.openClose.SampleVariance() + (1-k)*hv.terms.Mean()
+	}
+
+	if !hv.terms.Full() {
+		return
+	}
+
+	if variance < 0 {
+		variance = 0
+	}
+	hv.AddOutput(math.Sqrt(variance * hv.periodsPerYear))
+}
+
+// AddValue is not the preferred method for HistoricalVolatility, but can be used for
+// compatibility with the Indicator interface. It will use the value as open, high,
+// low and close, so only the close-to-close estimator gives meaningful results.
+func (hv *HistoricalVolatility) AddValue(value float64) {
+	hv.AddOpenHLCValue(value, value, value, value)
+}
+
+// GetEstimator returns the volatility estimator used by the indicator
+func (hv *HistoricalVolatility) GetEstimator() VolatilityEstimator {
+	return hv.estimator
+}
+
+// GetWindowSize returns the window size of the HistoricalVolatility
+func (hv *HistoricalVolatility) GetWindowSize() int {
+	return hv.windowSize
+}
+
+// Reset clears all values in the HistoricalVolatility
+func (hv *HistoricalVolatility) Reset() {
+	hv.BaseIndicator.Reset()
+	hv.prevClose = 0
+	hv.firstValueSet = false
+	hv.terms.Reset()
+	hv.overnight.Reset()
+	hv.openClose.Reset()
+}
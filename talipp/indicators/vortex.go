@@ -0,0 +1,125 @@
+package indicators
+
+import (
+	"math"
+)
+
+// Vortex represents the Vortex Indicator (+VI and -VI)
+type Vortex struct {
+	*BaseIndicator
+	windowSize    int
+	prevHigh      float64
+	prevLow       float64
+	prevClose     float64
+	firstValueSet bool
+	plusVM        *rollingStats
+	minusVM       *rollingStats
+	trueRange     *rollingStats
+	plusVI        []float64
+	minusVI       []float64
+}
+
+// VortexOutput represents the output of Vortex Indicator calculations
+type VortexOutput struct {
+	PlusVI  float64
+	MinusVI float64
+}
+
+// NewVortex creates a new Vortex Indicator with specified window size (default 14)
+func NewVortex(windowSize int) *Vortex {
+	if windowSize <= 0 {
+		panic("Window size must be greater than 0")
+	}
+
+	return &Vortex{
+		BaseIndicator: NewBaseIndicator("Vortex"),
+		windowSize:    windowSize,
+		plusVM:        newRollingStats(windowSize),
+		minusVM:       newRollingStats(windowSize),
+		trueRange:     newRollingStats(windowSize),
+		plusVI:        make([]float64, 0),
+		minusVI:       make([]float64, 0),
+	}
+}
+
+// AddOHLCValue adds a new OHLC candle data to the Vortex Indicator calculation
+func (vi *Vortex) AddOHLCValue(high, low, close float64) {
+	if vi.firstValueSet {
+		// Vortex movements: +VM = |high - previous low|, -VM = |low - previous high|
+		vi.plusVM.Add(math.Abs(high - vi.prevLow))
+		vi.minusVM.Add(math.Abs(low - vi.prevHigh))
+		vi.trueRange.Add(calculateTrueRange(high, low, vi.prevClose))
+	}
+
+	vi.prevHigh = high
+	vi.prevLow = low
+	vi.prevClose = close
+	vi.firstValueSet = true
+
+	if !vi.trueRange.Full() {
+		return
+	}
+
+	var plusVI, minusVI float64
+	if trueRangeSum := vi.trueRange.Sum(); trueRangeSum != 0 {
+		plusVI = vi.plusVM.Sum() / trueRangeSum
+		minusVI = vi.minusVM.Sum() / trueRangeSum
+	}
+	vi.plusVI = append(vi.plusVI, plusVI)
+	vi.minusVI = append(vi.minusVI, minusVI)
+
+	// The base indicator output is the difference +VI - -VI
+	vi.AddOutput(plusVI - minusVI)
+}
+
+// AddValue is not the preferred method for Vortex, but can be used for compatibility
+// with the Indicator interface. It will use the value as high, low and close.
+func (vi *Vortex) AddValue(value float64) {
+	vi.AddOHLCValue(value, value, value)
+}
+
+// GetWindowSize returns the window size of the Vortex Indicator
+func (vi *Vortex) GetWindowSize() int {
+	return vi.windowSize
+}
+
+// Reset clears all values in the Vortex Indicator
+func (vi *Vortex) Reset() {
+	vi.BaseIndicator.Reset()
+	vi.prevHigh = 0
+	vi.prevLow = 0
+	vi.prevClose = 0
+	vi.firstValueSet = false
+	vi.plusVM.Reset()
+	vi.minusVM.Reset()
+	vi.trueRange.Reset()
+	vi.plusVI = make([]float64, 0)
+	vi.minusVI = make([]float64, 0)
+}
+
+// GetVortexOutput returns the complete Vortex Indicator output (+VI, -VI)
+func (vi *Vortex) GetVortexOutput() []VortexOutput {
+	results := make([]VortexOutput, len(vi.plusVI))
+	for i := range vi.plusVI {
+		results[i] = VortexOutput{
+			PlusVI:  vi.plusVI[i],
+			MinusVI: vi.minusVI[i],
+		}
+	}
+
+	return results
+}
+
+// GetPlusVI returns just the +VI values
+func (vi *Vortex) GetPlusVI() []float64 {
+	result := make([]float64, len(vi.plusVI))
+	copy(result, vi.plusVI)
+	return result
+}
+
+// GetMinusVI returns just the -VI values
+func (vi *Vortex) GetMinusVI() []float64 {
+	result := make([]float64, len(vi.minusVI))
+	copy(result, vi.minusVI)
+	return result
+}
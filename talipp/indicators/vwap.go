@@ -0,0 +1,198 @@
+package indicators
+
+import (
+	"math"
+	"time"
+
+	"github.com/revanthstrakz/gotalipp/talipp/ohlcv"
+)
+
+// VWAP represents the Volume Weighted Average Price indicator.
+//
+// A session VWAP created with NewVWAP restarts at every session open. An
+// anchored VWAP created with NewAnchoredVWAP accumulates from the first candle
+// until it is re-anchored with Anchor or AnchorAt. Both can be re-anchored
+// mid-stream. The price of a candle is its typical price (H + L + C) / 3.
+type VWAP struct {
+	*BaseIndicator
+	location        *time.Location
+	sessionStart    time.Duration
+	sessions        bool
+	bandMultipliers []float64
+	currentSession  time.Time
+	anchorPending   bool
+	anchorAt        time.Time
+	cumVolume       float64
+	mean            float64
+	sumSquares      float64
+	results         []VWAPOutput
+}
+
+// VWAPOutput represents the output of VWAP calculations
+type VWAPOutput struct {
+	VWAP float64
+	// StdDev is the volume weighted standard deviation of price around the VWAP
+	StdDev float64
+	// Upper and Lower hold one band per configured multiplier, VWAP ± multiplier * StdDev
+	Upper []float64
+	Lower []float64
+}
+
+// VWAPOption configures optional VWAP parameters
+type VWAPOption func(*VWAP)
+
+// WithVWAPSession sets the session used by a session VWAP: the VWAP restarts
+// every day at the given offset from midnight in location (default midnight UTC)
+func WithVWAPSession(location *time.Location, start time.Duration) VWAPOption {
+	return func(vwap *VWAP) {
+		vwap.location = location
+		vwap.sessionStart = start
+	}
+}
+
+// WithVWAPBands sets the standard deviation multipliers of the VWAP bands (default 1, 2)
+func WithVWAPBands(multipliers ...float64) VWAPOption {
+	return func(vwap *VWAP) {
+		vwap.bandMultipliers = append([]float64(nil), multipliers...)
+	}
+}
+
+// NewVWAP creates a new session VWAP indicator
+func NewVWAP(opts ...VWAPOption) *VWAP {
+	vwap := newVWAP("VWAP", opts)
+	vwap.sessions = true
+	return vwap
+}
+
+// NewAnchoredVWAP creates a new anchored VWAP indicator, anchored on the first candle
+func NewAnchoredVWAP(opts ...VWAPOption) *VWAP {
+	return newVWAP("AnchoredVWAP", opts)
+}
+
+// newVWAP creates a VWAP with the given name and options
+func newVWAP(name string, opts []VWAPOption) *VWAP {
+	vwap := &VWAP{
+		BaseIndicator:   NewBaseIndicator(name),
+		location:        time.UTC,
+		bandMultipliers: []float64{1.0, 2.0},
+		results:         make([]VWAPOutput, 0),
+	}
+	for _, opt := range opts {
+		opt(vwap)
+	}
+	if vwap.location == nil {
+		panic("Session location must not be nil")
+	}
+
+	return vwap
+}
+
+// Anchor restarts the accumulation so that the next candle is the new anchor
+func (vwap *VWAP) Anchor() {
+	vwap.resetAccumulation()
+	vwap.anchorPending = false
+}
+
+// AnchorAt restarts the accumulation at the first candle whose timestamp is
+// not before t. This allows anchoring on a known future event.
+func (vwap *VWAP) AnchorAt(t time.Time) {
+	vwap.anchorPending = true
+	vwap.anchorAt = t
+}
+
+// AddCandle adds a new candle to the VWAP calculation
+func (vwap *VWAP) AddCandle(candle *ohlcv.OHLCV) {
+	if vwap.anchorPending && !candle.Timestamp.Before(vwap.anchorAt) {
+		vwap.Anchor()
+	}
+
+	if vwap.sessions {
+		session := vwap.sessionOf(candle.Timestamp)
+		if !session.Equal(vwap.currentSession) {
+			vwap.resetAccumulation()
+			vwap.currentSession = session
+		}
+	}
+
+	typical := (candle.High + candle.Low + candle.Close) / 3.0
+	vwap.AddPriceVolumeValue(typical, candle.Volume)
+}
+
+// AddPriceVolumeValue adds a new price and volume to the VWAP calculation
+// without any session handling
+func (vwap *VWAP) AddPriceVolumeValue(price, volume float64) {
+	// Weighted incremental mean and variance (West's algorithm)
+	if volume > 0 {
+		vwap.cumVolume += volume
+		delta := price - vwap.mean
+		vwap.mean += delta * volume / vwap.cumVolume
+		vwap.sumSquares += volume * delta * (price - vwap.mean)
+	} else if vwap.cumVolume == 0 {
+		// Without any volume yet the price is the best estimate
+		vwap.mean = price
+	}
+
+	var stdDev float64
+	if vwap.cumVolume > 0 {
+		stdDev = math.Sqrt(math.Max(vwap.sumSquares/vwap.cumVolume, 0))
+	}
+
+	output := VWAPOutput{
+		VWAP:   vwap.mean,
+		StdDev: stdDev,
+		Upper:  make([]float64, len(vwap.bandMultipliers)),
+		Lower:  make([]float64, len(vwap.bandMultipliers)),
+	}
+	for i, multiplier := range vwap.bandMultipliers {
+		output.Upper[i] = vwap.mean + multiplier*stdDev
+		output.Lower[i] = vwap.mean - multiplier*stdDev
+	}
+
+	vwap.results = append(vwap.results, output)
+	vwap.AddOutput(vwap.mean)
+}
+
+// AddValue is not the preferred method for VWAP, but can be used for compatibility
+// with the Indicator interface. Every value gets a volume of 1 and no session
+// handling is applied.
+func (vwap *VWAP) AddValue(value float64) {
+	vwap.AddPriceVolumeValue(value, 1.0)
+}
+
+// sessionOf returns the start of the session containing t
+func (vwap *VWAP) sessionOf(t time.Time) time.Time {
+	return sessionStart(t, vwap.location, vwap.sessionStart, SessionDaily)
+}
+
+// resetAccumulation clears the running volume and price statistics
+func (vwap *VWAP) resetAccumulation() {
+	vwap.cumVolume = 0
+	vwap.mean = 0
+	vwap.sumSquares = 0
+}
+
+// Reset clears all values in the VWAP
+func (vwap *VWAP) Reset() {
+	vwap.BaseIndicator.Reset()
+	vwap.resetAccumulation()
+	vwap.currentSession = time.Time{}
+	vwap.anchorPending = false
+	vwap.anchorAt = time.Time{}
+	vwap.results = make([]VWAPOutput, 0)
+}
+
+// GetVWAPOutput returns the VWAP, standard deviation and bands for every candle
+func (vwap *VWAP) GetVWAPOutput() []VWAPOutput {
+	result := make([]VWAPOutput, len(vwap.results))
+	for i, output := range vwap.results {
+		result[i] = copyVWAPOutput(output)
+	}
+	return result
+}
+
+// copyVWAPOutput returns a copy of output that does not share its bands
+func copyVWAPOutput(output VWAPOutput) VWAPOutput {
+	output.Upper = append([]float64(nil), output.Upper...)
+	output.Lower = append([]float64(nil), output.Lower...)
+	return output
+}
@@ -0,0 +1,57 @@
+package indicators
+
+// VWMA represents a Volume Weighted Moving Average indicator
+type VWMA struct {
+	*BaseIndicator
+	windowSize int
+	priceVol   *rollingStats
+	volume     *rollingStats
+}
+
+// NewVWMA creates a new Volume Weighted Moving Average indicator with specified window size
+func NewVWMA(windowSize int) *VWMA {
+	if windowSize <= 0 {
+		panic("Window size must be greater than 0")
+	}
+
+	return &VWMA{
+		BaseIndicator: NewBaseIndicator("VWMA"),
+		windowSize:    windowSize,
+		priceVol:      newRollingStats(windowSize),
+		volume:        newRollingStats(windowSize),
+	}
+}
+
+// AddValue is not the preferred method for VWMA, but can be used for compatibility
+// with the Indicator interface. Every value gets a volume of 1, which gives the SMA.
+func (vwma *VWMA) AddValue(value float64) {
+	vwma.AddValueVolume(value, 1.0)
+}
+
+// AddValueVolume adds a new price and its volume to the VWMA calculation
+func (vwma *VWMA) AddValueVolume(value, volume float64) {
+	vwma.priceVol.Add(value * volume)
+	vwma.volume.Add(volume)
+
+	if vwma.volume.Full() {
+		totalVolume := vwma.volume.Sum()
+		if totalVolume == 0 {
+			// Without volume fall back to the plain average
+			vwma.AddOutput(vwma.priceVol.Mean())
+			return
+		}
+		vwma.AddOutput(vwma.priceVol.Sum() / totalVolume)
+	}
+}
+
+// GetWindowSize returns the window size of the VWMA
+func (vwma *VWMA) GetWindowSize() int {
+	return vwma.windowSize
+}
+
+// Reset clears all values in the VWMA
+func (vwma *VWMA) Reset() {
+	vwma.BaseIndicator.Reset()
+	vwma.priceVol.Reset()
+	vwma.volume.Reset()
+}
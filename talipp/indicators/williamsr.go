@@ -0,0 +1,55 @@
+package indicators
+
+// WilliamsR represents the Williams %R indicator, ranging from -100 to 0
+type WilliamsR struct {
+	*BaseIndicator
+	windowSize int
+	extremes   *rollingExtremes
+}
+
+// NewWilliamsR creates a new Williams %R indicator with specified window size (default 14)
+func NewWilliamsR(windowSize int) *WilliamsR {
+	if windowSize <= 0 {
+		panic("Window size must be greater than 0")
+	}
+
+	return &WilliamsR{
+		BaseIndicator: NewBaseIndicator("WilliamsR"),
+		windowSize:    windowSize,
+		extremes:      newRollingExtremes(windowSize),
+	}
+}
+
+// AddOHLCValue adds a new OHLC candle data to the Williams %R calculation
+func (wr *WilliamsR) AddOHLCValue(high, low, close float64) {
+	wr.extremes.Add(high, low)
+
+	if wr.extremes.Full() {
+		highestHigh := wr.extremes.Max()
+		lowestLow := wr.extremes.Min()
+
+		// %R = -100 * (highest high - close) / (highest high - lowest low)
+		value := -50.0 // To avoid division by zero
+		if highestHigh != lowestLow {
+			value = -100.0 * (highestHigh - close) / (highestHigh - lowestLow)
+		}
+		wr.AddOutput(value)
+	}
+}
+
+// AddValue is not the preferred method for Williams %R, but can be used for compatibility
+// with the Indicator interface. It will use the value as high, low and close.
+func (wr *WilliamsR) AddValue(value float64) {
+	wr.AddOHLCValue(value, value, value)
+}
+
+// GetWindowSize returns the window size of the Williams %R
+func (wr *WilliamsR) GetWindowSize() int {
+	return wr.windowSize
+}
+
+// Reset clears all values in the Williams %R
+func (wr *WilliamsR) Reset() {
+	wr.BaseIndicator.Reset()
+	wr.extremes.Reset()
+}
@@ -0,0 +1,74 @@
+package indicators
+
+import "math"
+
+// WMA represents a linearly Weighted Moving Average indicator. The newest
+// value has weight windowSize and the oldest value has weight 1.
+type WMA struct {
+	*BaseIndicator
+	windowSize  int
+	stats       *rollingStats
+	weightedSum float64
+	denominator float64
+	updates     int
+}
+
+// NewWMA creates a new Weighted Moving Average indicator with specified window size
+func NewWMA(windowSize int) *WMA {
+	if windowSize <= 0 {
+		panic("Window size must be greater than 0")
+	}
+
+	return &WMA{
+		BaseIndicator: NewBaseIndicator("WMA"),
+		windowSize:    windowSize,
+		stats:         newRollingStats(windowSize),
+		denominator:   float64(windowSize*(windowSize+1)) / 2.0,
+	}
+}
+
+// AddValue adds a new value to the WMA calculation
+func (wma *WMA) AddValue(value float64) {
+	prevSum := wma.stats.Sum()
+	_, evicted := wma.stats.Add(value)
+	if !wma.stats.Full() {
+		return
+	}
+
+	wma.updates++
+	if wma.stats.HasNonFinite() {
+		// The weighted sum is recomputed once the NaN or infinite values
+		// have left the window
+		wma.updates = wma.windowSize
+		wma.AddOutput(math.NaN())
+		return
+	}
+	if !evicted || wma.updates >= wma.windowSize {
+		// Compute the weighted sum from the window, which also bounds the
+		// rounding error of the incremental updates below
+		wma.weightedSum = 0
+		for i := 0; i < wma.windowSize; i++ {
+			wma.weightedSum += float64(i+1) * wma.stats.At(i)
+		}
+		wma.updates = 0
+	} else {
+		// Every remaining value loses one unit of weight and the new value
+		// enters with the full weight
+		wma.weightedSum += float64(wma.windowSize)*value - prevSum
+	}
+
+	wma.AddOutput(wma.weightedSum / wma.denominator)
+}
+
+// GetWindowSize returns the window size of the WMA
+func (wma *WMA) GetWindowSize() int {
+	return wma.windowSize
+}
+
+// Reset clears all values in the WMA
+func (wma *WMA) Reset() {
+	wma.BaseIndicator.Reset()
+	wma.stats.Reset()
+	wma.weightedSum = 0
+	wma.updates = 0
+}
@@ -0,0 +1,183 @@
+package indicators
+
+import (
+	"time"
+
+	"github.com/revanthstrakz/gotalipp/talipp/ohlcv"
+)
+
+// ZigZag represents the ZigZag indicator, which connects the swing highs and
+// lows that are separated by at least a reversal threshold.
+//
+// The last swing is only confirmed once prices reverse from it by the
+// threshold; until then it is tentative and moves with every new extreme, so
+// GetTentative repaints while GetSwings never changes. The base indicator
+// output holds the price of every confirmed swing.
+type ZigZag struct {
+	*BaseIndicator
+	swings
+	percent    float64
+	multiplier float64
+	atr        *ATR
+	direction  int
+	highPoint  SwingPoint
+	lowPoint   SwingPoint
+	started    bool
+}
+
+// NewZigZag creates a new ZigZag indicator reversing on a move of percent
+// percent from the last extreme (default 5.0)
+func NewZigZag(percent float64) *ZigZag {
+	if percent <= 0 {
+		panic("Percent must be greater than 0")
+	}
+
+	return &ZigZag{
+		BaseIndicator: NewBaseIndicator("ZigZag"),
+		swings:        newSwings(),
+		percent:       percent,
+	}
+}
+
+// NewZigZagATR creates a new ZigZag indicator reversing on a move of a
+// multiple of the ATR from the last extreme
+// atrWindow: the period for the ATR (default 14)
+// multiplier: the ATR multiple (default 3.0)
+func NewZigZagATR(atrWindow int, multiplier float64, opts ...ATROption) *ZigZag {
+	if multiplier <= 0 {
+		panic("Multiplier must be greater than 0")
+	}
+
+	return &ZigZag{
+		BaseIndicator: NewBaseIndicator("ZigZagATR"),
+		swings:        newSwings(),
+		multiplier:    multiplier,
+		atr:           NewATR(atrWindow, opts...),
+	}
+}
+
+// AddCandle adds a new candle to the ZigZag calculation
+func (zz *ZigZag) AddCandle(candle *ohlcv.OHLCV) {
+	zz.addBar(candle.High, candle.Low, candle.Close, candle.Timestamp)
+}
+
+// AddOHLCValue adds a new OHLC candle data to the ZigZag calculation
+func (zz *ZigZag) AddOHLCValue(high, low, close float64) {
+	zz.addBar(high, low, close, time.Time{})
+}
+
+// AddValue is not the preferred method for ZigZag, but can be used for compatibility
+// with the Indicator interface. It will use the value as high, low and close.
+func (zz *ZigZag) AddValue(value float64) {
+	zz.AddOHLCValue(value, value, value)
+}
+
+// addBar adds a new bar to the ZigZag calculation
+func (zz *ZigZag) addBar(high, low, close float64, timestamp time.Time) {
+	index := zz.bars
+	zz.bars++
+	if zz.atr != nil {
+		zz.atr.AddOHLCValue(high, low, close)
+	}
+
+	highPoint := SwingPoint{Kind: SwingHigh, Price: high, Index: index, Timestamp: timestamp, ConfirmedIndex: -1}
+	lowPoint := SwingPoint{Kind: SwingLow, Price: low, Index: index, Timestamp: timestamp, ConfirmedIndex: -1}
+	if !zz.started {
+		zz.highPoint = highPoint
+		zz.lowPoint = lowPoint
+		zz.started = true
+		return
+	}
+
+	switch zz.direction {
+	case 0:
+		// Until the first reversal both extremes are candidates
+		if high > zz.highPoint.Price {
+			zz.highPoint = highPoint
+		}
+		if low < zz.lowPoint.Price {
+			zz.lowPoint = lowPoint
+		}
+		threshold, ok := zz.threshold(zz.lowPoint.Price)
+		if ok && zz.lowPoint.Index < index && high-zz.lowPoint.Price >= threshold {
+			zz.confirmSwing(zz.lowPoint)
+			zz.highPoint = highPoint
+			zz.direction = 1
+			return
+		}
+		threshold, ok = zz.threshold(zz.highPoint.Price)
+		if ok && zz.highPoint.Index < index && zz.highPoint.Price-low >= threshold {
+			zz.confirmSwing(zz.highPoint)
+			zz.lowPoint = lowPoint
+			zz.direction = -1
+		}
+
+	case 1:
+		if high > zz.highPoint.Price {
+			zz.highPoint = highPoint
+			return
+		}
+		if threshold, ok := zz.threshold(zz.highPoint.Price); ok && zz.highPoint.Price-low >= threshold {
+			zz.confirmSwing(zz.highPoint)
+			zz.lowPoint = lowPoint
+			zz.direction = -1
+		}
+
+	case -1:
+		if low < zz.lowPoint.Price {
+			zz.lowPoint = lowPoint
+			return
+		}
+		if threshold, ok := zz.threshold(zz.lowPoint.Price); ok && high-zz.lowPoint.Price >= threshold {
+			zz.confirmSwing(zz.lowPoint)
+			zz.highPoint = highPoint
+			zz.direction = 1
+		}
+	}
+}
+
+// threshold returns the reversal distance from an extreme, or false while the ATR is not initialized
+func (zz *ZigZag) threshold(extreme float64) (float64, bool) {
+	if zz.atr == nil {
+		return extreme * zz.percent / 100.0, true
+	}
+
+	atrValue, err := zz.atr.GetLastValue()
+	if err != nil {
+		return 0, false
+	}
+	return zz.multiplier * atrValue, true
+}
+
+// confirmSwing confirms a swing point and adds its price to the output
+func (zz *ZigZag) confirmSwing(point SwingPoint) {
+	zz.confirm(point)
+	zz.AddOutput(point.Price)
+}
+
+// GetTentative returns the swing the ZigZag is currently extending, which
+// is not confirmed yet and may still move or be replaced. It returns an
+// empty slice before the first swing is confirmed.
+func (zz *ZigZag) GetTentative() []SwingPoint {
+	switch zz.direction {
+	case 1:
+		return []SwingPoint{zz.highPoint}
+	case -1:
+		return []SwingPoint{zz.lowPoint}
+	default:
+		return []SwingPoint{}
+	}
+}
+
+// Reset clears all values in the ZigZag
+func (zz *ZigZag) Reset() {
+	zz.BaseIndicator.Reset()
+	zz.resetSwings()
+	if zz.atr != nil {
+		zz.atr.Reset()
+	}
+	zz.direction = 0
+	zz.highPoint = SwingPoint{}
+	zz.lowPoint = SwingPoint{}
+	zz.started = false
+}
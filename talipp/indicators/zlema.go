@@ -0,0 +1,57 @@
+package indicators
+
+// ZLEMA represents a Zero Lag Exponential Moving Average indicator. The EMA is
+// applied to the de-lagged series 2 * value - value[lag] with lag = (windowSize - 1) / 2.
+type ZLEMA struct {
+	*BaseIndicator
+	windowSize int
+	lag        int
+	ema        *EMA
+	values     []float64
+}
+
+// NewZLEMA creates a new Zero Lag Exponential Moving Average indicator with specified window size
+func NewZLEMA(windowSize int) *ZLEMA {
+	if windowSize <= 0 {
+		panic("Window size must be greater than 0")
+	}
+
+	return &ZLEMA{
+		BaseIndicator: NewBaseIndicator("ZLEMA"),
+		windowSize:    windowSize,
+		lag:           (windowSize - 1) / 2,
+		ema:           NewEMA(windowSize),
+		values:        make([]float64, 0),
+	}
+}
+
+// AddValue adds a new value to the ZLEMA calculation
+func (zlema *ZLEMA) AddValue(value float64) {
+	zlema.values = append(zlema.values, value)
+
+	// Keep only the values needed for the lag
+	if len(zlema.values) > zlema.lag+1 {
+		zlema.values = zlema.values[1:]
+	}
+	if len(zlema.values) <= zlema.lag {
+		return
+	}
+
+	zlema.ema.AddValue(2.0*value - zlema.values[0])
+	if zlema.ema.IsInitialized() {
+		emaValue, _ := zlema.ema.GetLastValue()
+		zlema.AddOutput(emaValue)
+	}
+}
+
+// GetWindowSize returns the window size of the ZLEMA
+func (zlema *ZLEMA) GetWindowSize() int {
+	return zlema.windowSize
+}
+
+// Reset clears all values in the ZLEMA
+func (zlema *ZLEMA) Reset() {
+	zlema.BaseIndicator.Reset()
+	zlema.ema.Reset()
+	zlema.values = make([]float64, 0)
+}
@@ -0,0 +1,47 @@
+package indicators
+
+// ZScore represents a rolling z-score indicator: how many standard deviations
+// the latest value is away from the mean of the window
+type ZScore struct {
+	*BaseIndicator
+	windowSize int
+	stats      *rollingStats
+}
+
+// NewZScore creates a new rolling z-score indicator with specified window size (default 20)
+func NewZScore(windowSize int) *ZScore {
+	if windowSize <= 0 {
+		panic("Window size must be greater than 0")
+	}
+
+	return &ZScore{
+		BaseIndicator: NewBaseIndicator("ZScore"),
+		windowSize:    windowSize,
+		stats:         newRollingStats(windowSize),
+	}
+}
+
+// AddValue adds a new value to the z-score calculation
+func (zs *ZScore) AddValue(value float64) {
+	zs.stats.Add(value)
+
+	if zs.stats.Full() {
+		// z = (value - mean) / population standard deviation
+		var zScore float64 // 0 when all values of the window are equal
+		if stdDev := zs.stats.StdDev(); stdDev != 0 {
+			zScore = (value - zs.stats.Mean()) / stdDev
+		}
+		zs.AddOutput(zScore)
+	}
+}
+
+// GetWindowSize returns the window size of the ZScore
+func (zs *ZScore) GetWindowSize() int {
+	return zs.windowSize
+}
+
+// Reset clears all values in the ZScore
+func (zs *ZScore) Reset() {
+	zs.BaseIndicator.Reset()
+	zs.stats.Reset()
+}
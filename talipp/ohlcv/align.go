@@ -0,0 +1,101 @@
+package ohlcv
+
+// AlignByTimestamp returns the candles of a and b that share a timestamp, as
+// two slices of the same length where the candles at the same index belong to
+// the same timestamp. Both inputs must be sorted by timestamp; candles without
+// a counterpart in the other series are skipped.
+func AlignByTimestamp(a, b []*OHLCV) ([]*OHLCV, []*OHLCV) {
+	alignedA := make([]*OHLCV, 0)
+	alignedB := make([]*OHLCV, 0)
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i].Timestamp.Before(b[j].Timestamp):
+			i++
+		case b[j].Timestamp.Before(a[i].Timestamp):
+			j++
+		default:
+			alignedA = append(alignedA, a[i])
+			alignedB = append(alignedB, b[j])
+			i++
+			j++
+		}
+	}
+
+	return alignedA, alignedB
+}
+
+// Aligner pairs the candles of two live series by timestamp as they arrive,
+// e.g. an asset and its benchmark fed from two streams:
+//
+//	aligner := ohlcv.NewAligner(func(asset, benchmark *ohlcv.OHLCV) {
+//	    beta.AddPairValue(asset.Close, benchmark.Close)
+//	})
+//	assetStream.OnUpdate(aligner.AddA)
+//	benchmarkStream.OnUpdate(aligner.AddB)
+//
+// Each series must deliver its candles in timestamp order. A candle is held
+// until the candle with the same timestamp arrives on the other side, and is
+// dropped as soon as the other side moves past its timestamp.
+type Aligner struct {
+	pendingA []*OHLCV
+	pendingB []*OHLCV
+	onPair   func(a, b *OHLCV)
+}
+
+// NewAligner creates a new Aligner calling onPair for every matched pair of candles
+func NewAligner(onPair func(a, b *OHLCV)) *Aligner {
+	return &Aligner{
+		pendingA: make([]*OHLCV, 0),
+		pendingB: make([]*OHLCV, 0),
+		onPair:   onPair,
+	}
+}
+
+// AddA adds the next candle of the first series
+func (al *Aligner) AddA(candle *OHLCV) {
+	al.pendingB = al.match(candle, al.pendingB, &al.pendingA, func(other *OHLCV) {
+		al.onPair(candle, other)
+	})
+}
+
+// AddB adds the next candle of the second series
+func (al *Aligner) AddB(candle *OHLCV) {
+	al.pendingA = al.match(candle, al.pendingA, &al.pendingB, func(other *OHLCV) {
+		al.onPair(other, candle)
+	})
+}
+
+// match looks for the counterpart of candle among the candles pending on the
+// other side and returns what remains pending there. When there is no
+// counterpart yet the candle is queued on its own side.
+func (al *Aligner) match(candle *OHLCV, others []*OHLCV, own *[]*OHLCV, emit func(*OHLCV)) []*OHLCV {
+	// Older candles of the other side can no longer be matched
+	for len(others) > 0 && others[0].Timestamp.Before(candle.Timestamp) {
+		others = others[1:]
+	}
+
+	if len(others) > 0 {
+		if others[0].Timestamp.Equal(candle.Timestamp) {
+			emit(others[0])
+			others = others[1:]
+		}
+		// Otherwise the other side is already past this candle, which is dropped
+		return others
+	}
+
+	*own = append(*own, candle)
+	return others
+}
+
+// Pending returns how many candles of each series are waiting for their counterpart
+func (al *Aligner) Pending() (int, int) {
+	return len(al.pendingA), len(al.pendingB)
+}
+
+// Reset drops all pending candles
+func (al *Aligner) Reset() {
+	al.pendingA = make([]*OHLCV, 0)
+	al.pendingB = make([]*OHLCV, 0)
+}
@@ -29,8 +29,9 @@ func NewOHLCV(timestamp time.Time, open, high, low, close, volume float64) *OHLC
 
 // Stream represents a stream of OHLCV data
 type Stream struct {
-	Data    []*OHLCV
-	onUpdate func(*OHLCV)
+	Data        []*OHLCV
+	onUpdate    func(*OHLCV)
+	subscribers []func(*OHLCV)
 }
 
 // NewStream creates a new OHLCV stream
@@ -47,13 +48,24 @@ func (s *Stream) Add(candle *OHLCV) {
 	if s.onUpdate != nil {
 		s.onUpdate(candle)
 	}
+	for _, subscriber := range s.subscribers {
+		subscriber(candle)
+	}
 }
 
-// OnUpdate sets a callback to be called when new data is added
+// OnUpdate sets a callback to be called when new data is added, replacing the
+// previous one. Use Subscribe to register several independent callbacks.
 func (s *Stream) OnUpdate(callback func(*OHLCV)) {
 	s.onUpdate = callback
 }
 
+// Subscribe registers an additional callback to be called when new data is
+// added. Subscribers are called in registration order, after the OnUpdate
+// callback.
+func (s *Stream) Subscribe(callback func(*OHLCV)) {
+	s.subscribers = append(s.subscribers, callback)
+}
+
 // Size returns the number of candles in the stream
 func (s *Stream) Size() int {
 	return len(s.Data)
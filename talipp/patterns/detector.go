@@ -0,0 +1,314 @@
+package patterns
+
+import (
+	"github.com/revanthstrakz/gotalipp/talipp/indicators"
+	"github.com/revanthstrakz/gotalipp/talipp/ohlcv"
+)
+
+// historySize is the number of bars kept by the detector: the three candles
+// of the longest patterns and the trend before them
+const historySize = 4
+
+// Detector recognises candlestick patterns on a stream of candles.
+//
+// Reversal patterns are only reported against the prevailing trend, which is
+// the position of the close relative to a moving average on the bar before
+// the pattern: bullish patterns need a close below the average and bearish
+// patterns a close above it. No reversal pattern is reported until the moving
+// average is initialized. When the trend context is disabled with
+// WithoutTrend, reversal patterns are reported on their shape alone and the
+// small body candles with a long shadow are reported as Hammer and
+// InvertedHammer.
+type Detector struct {
+	dojiBodyRatio    float64
+	longShadowRatio  float64
+	shortShadowRatio float64
+	longBodyRatio    float64
+	starBodyRatio    float64
+	trendMAType      indicators.MAType
+	trendWindow      int
+	trendMA          indicators.MovingAverage
+	bars             int
+	candles          []*ohlcv.OHLCV
+	trends           []Direction
+	signals          []Signal
+	onSignal         func(Signal)
+}
+
+// DetectorOption configures optional Detector parameters
+type DetectorOption func(*Detector)
+
+// WithDojiBodyRatio sets the largest body, as a fraction of the candle range,
+// of a doji (default 0.1)
+func WithDojiBodyRatio(ratio float64) DetectorOption {
+	return func(d *Detector) {
+		d.dojiBodyRatio = ratio
+	}
+}
+
+// WithShadowRatios sets the shadow thresholds of the hammer family: the long
+// shadow must be at least longRatio times the body (default 2.0) and the
+// opposite shadow at most shortRatio times the candle range (default 0.1)
+func WithShadowRatios(longRatio, shortRatio float64) DetectorOption {
+	return func(d *Detector) {
+		d.longShadowRatio = longRatio
+		d.shortShadowRatio = shortRatio
+	}
+}
+
+// WithLongBodyRatio sets the smallest body, as a fraction of the candle range,
+// of the long candles in harami, star and three soldiers/crows patterns (default 0.6)
+func WithLongBodyRatio(ratio float64) DetectorOption {
+	return func(d *Detector) {
+		d.longBodyRatio = ratio
+	}
+}
+
+// WithStarBodyRatio sets the largest body of the star candle, as a fraction of
+// the body of the first candle, in morning and evening stars (default 0.3)
+func WithStarBodyRatio(ratio float64) DetectorOption {
+	return func(d *Detector) {
+		d.starBodyRatio = ratio
+	}
+}
+
+// WithTrend sets the moving average giving the trend context (default SMA of 10 bars)
+func WithTrend(maType indicators.MAType, windowSize int) DetectorOption {
+	return func(d *Detector) {
+		d.trendMAType = maType
+		d.trendWindow = windowSize
+	}
+}
+
+// WithoutTrend disables the trend context of reversal patterns
+func WithoutTrend() DetectorOption {
+	return func(d *Detector) {
+		d.trendWindow = 0
+	}
+}
+
+// NewDetector creates a new candlestick pattern detector
+func NewDetector(opts ...DetectorOption) *Detector {
+	d := &Detector{
+		dojiBodyRatio:    0.1,
+		longShadowRatio:  2.0,
+		shortShadowRatio: 0.1,
+		longBodyRatio:    0.6,
+		starBodyRatio:    0.3,
+		trendMAType:      indicators.MATypeSMA,
+		trendWindow:      10,
+		candles:          make([]*ohlcv.OHLCV, 0, historySize),
+		trends:           make([]Direction, 0, historySize),
+		signals:          make([]Signal, 0),
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	if d.trendWindow > 0 {
+		d.trendMA = indicators.NewMovingAverage(d.trendMAType, d.trendWindow)
+	}
+
+	return d
+}
+
+// Detect runs a new detector over a series of candles, such as the Data of an
+// ohlcv.Stream, and returns all detected signals
+func Detect(candles []*ohlcv.OHLCV, opts ...DetectorOption) []Signal {
+	d := NewDetector(opts...)
+	for _, candle := range candles {
+		d.AddCandle(candle)
+	}
+	return d.GetSignals()
+}
+
+// AddCandle adds a new candle to the detector and returns the signals of
+// the patterns completed by this candle
+func (d *Detector) AddCandle(candle *ohlcv.OHLCV) []Signal {
+	d.candles = append(d.candles, candle)
+	if len(d.candles) > historySize {
+		d.candles = d.candles[1:]
+	}
+
+	found := make([]Signal, 0)
+	emit := func(pattern Pattern, direction Direction) {
+		found = append(found, Signal{
+			Index:     d.bars,
+			Timestamp: candle.Timestamp,
+			Pattern:   pattern,
+			Direction: direction,
+		})
+	}
+
+	d.detectSingle(candle, emit)
+	if len(d.candles) >= 2 {
+		d.detectDouble(d.candles[len(d.candles)-2], candle, emit)
+	}
+	if len(d.candles) >= 3 {
+		d.detectTriple(d.candles[len(d.candles)-3], d.candles[len(d.candles)-2], candle, emit)
+	}
+
+	// The trend of this bar is the context of patterns starting on the next bar
+	d.trends = append(d.trends, d.trendOf(candle))
+	if len(d.trends) > historySize {
+		d.trends = d.trends[1:]
+	}
+
+	d.bars++
+	d.signals = append(d.signals, found...)
+	if d.onSignal != nil {
+		for _, signal := range found {
+			d.onSignal(signal)
+		}
+	}
+	return found
+}
+
+// OnSignal sets a callback to be called with every new signal
+func (d *Detector) OnSignal(callback func(Signal)) {
+	d.onSignal = callback
+}
+
+// Attach subscribes the detector to a stream so that patterns are detected
+// on every candle added to the stream
+func (d *Detector) Attach(stream *ohlcv.Stream) {
+	stream.Subscribe(func(candle *ohlcv.OHLCV) {
+		d.AddCandle(candle)
+	})
+}
+
+// trendOf updates the trend moving average and returns the trend of the candle
+func (d *Detector) trendOf(candle *ohlcv.OHLCV) Direction {
+	if d.trendMA == nil {
+		return Neutral
+	}
+
+	d.trendMA.AddValue(candle.Close)
+	average, err := d.trendMA.GetLastValue()
+	if err != nil {
+		return Neutral
+	}
+
+	switch {
+	case candle.Close > average:
+		return Bullish
+	case candle.Close < average:
+		return Bearish
+	default:
+		return Neutral
+	}
+}
+
+// reversalAllowed returns whether a reversal pattern of the given direction
+// made of the last size candles goes against the trend preceding it
+func (d *Detector) reversalAllowed(direction Direction, size int) bool {
+	if d.trendMA == nil {
+		return true
+	}
+
+	// d.trends does not hold the current bar yet
+	i := len(d.trends) - size
+	if i < 0 {
+		return false
+	}
+	return d.trends[i] == -direction
+}
+
+// detectSingle detects the one candle patterns
+func (d *Detector) detectSingle(c *ohlcv.OHLCV, emit func(Pattern, Direction)) {
+	r := candleRange(c)
+	if r <= 0 {
+		return
+	}
+
+	if body(c) <= d.dojiBodyRatio*r {
+		emit(PatternDoji, Neutral)
+	}
+
+	// Hammer family: a long shadow on one side and almost none on the other
+	if lowerShadow(c) >= d.longShadowRatio*body(c) && upperShadow(c) <= d.shortShadowRatio*r {
+		if d.reversalAllowed(Bullish, 1) {
+			emit(PatternHammer, Bullish)
+		} else if d.trendMA != nil && d.reversalAllowed(Bearish, 1) {
+			emit(PatternHangingMan, Bearish)
+		}
+	}
+	if upperShadow(c) >= d.longShadowRatio*body(c) && lowerShadow(c) <= d.shortShadowRatio*r {
+		if d.reversalAllowed(Bullish, 1) {
+			emit(PatternInvertedHammer, Bullish)
+		} else if d.trendMA != nil && d.reversalAllowed(Bearish, 1) {
+			emit(PatternShootingStar, Bearish)
+		}
+	}
+}
+
+// detectDouble detects the two candle patterns
+func (d *Detector) detectDouble(p, c *ohlcv.OHLCV, emit func(Pattern, Direction)) {
+	// Engulfing: the body covers the opposite coloured previous body
+	if isBearish(p) && isBullish(c) && c.Open <= p.Close && c.Close >= p.Open && body(c) > body(p) &&
+		d.reversalAllowed(Bullish, 2) {
+		emit(PatternEngulfing, Bullish)
+	}
+	if isBullish(p) && isBearish(c) && c.Open >= p.Close && c.Close <= p.Open && body(c) > body(p) &&
+		d.reversalAllowed(Bearish, 2) {
+		emit(PatternEngulfing, Bearish)
+	}
+
+	// Harami: a smaller opposite coloured body inside a long previous body
+	if body(p) >= d.longBodyRatio*candleRange(p) && body(c) < body(p) &&
+		bodyTop(c) <= bodyTop(p) && bodyBottom(c) >= bodyBottom(p) {
+		if isBearish(p) && isBullish(c) && d.reversalAllowed(Bullish, 2) {
+			emit(PatternHarami, Bullish)
+		}
+		if isBullish(p) && isBearish(c) && d.reversalAllowed(Bearish, 2) {
+			emit(PatternHarami, Bearish)
+		}
+	}
+}
+
+// detectTriple detects the three candle patterns
+func (d *Detector) detectTriple(pp, p, c *ohlcv.OHLCV, emit func(Pattern, Direction)) {
+	firstIsLong := body(pp) >= d.longBodyRatio*candleRange(pp)
+	starIsSmall := body(p) <= d.starBodyRatio*body(pp)
+	firstMiddle := (pp.Open + pp.Close) / 2.0
+
+	if firstIsLong && starIsSmall && isBearish(pp) && bodyTop(p) <= pp.Close &&
+		isBullish(c) && c.Close > firstMiddle && d.reversalAllowed(Bullish, 3) {
+		emit(PatternMorningStar, Bullish)
+	}
+	if firstIsLong && starIsSmall && isBullish(pp) && bodyBottom(p) >= pp.Close &&
+		isBearish(c) && c.Close < firstMiddle && d.reversalAllowed(Bearish, 3) {
+		emit(PatternEveningStar, Bearish)
+	}
+
+	allLong := firstIsLong && body(p) >= d.longBodyRatio*candleRange(p) && body(c) >= d.longBodyRatio*candleRange(c)
+	if allLong && isBullish(pp) && isBullish(p) && isBullish(c) &&
+		p.Close > pp.Close && c.Close > p.Close &&
+		p.Open >= pp.Open && p.Open <= pp.Close && c.Open >= p.Open && c.Open <= p.Close &&
+		d.reversalAllowed(Bullish, 3) {
+		emit(PatternThreeWhiteSoldiers, Bullish)
+	}
+	if allLong && isBearish(pp) && isBearish(p) && isBearish(c) &&
+		p.Close < pp.Close && c.Close < p.Close &&
+		p.Open <= pp.Open && p.Open >= pp.Close && c.Open <= p.Open && c.Open >= p.Close &&
+		d.reversalAllowed(Bearish, 3) {
+		emit(PatternThreeBlackCrows, Bearish)
+	}
+}
+
+// GetSignals returns all signals detected so far
+func (d *Detector) GetSignals() []Signal {
+	result := make([]Signal, len(d.signals))
+	copy(result, d.signals)
+	return result
+}
+
+// Reset clears all candles and signals of the detector
+func (d *Detector) Reset() {
+	d.bars = 0
+	d.candles = make([]*ohlcv.OHLCV, 0, historySize)
+	d.trends = make([]Direction, 0, historySize)
+	d.signals = make([]Signal, 0)
+	if d.trendMA != nil {
+		d.trendMA.Reset()
+	}
+}
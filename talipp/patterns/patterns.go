@@ -0,0 +1,156 @@
+// Package patterns provides candlestick pattern recognition over OHLCV candles
+package patterns
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/revanthstrakz/gotalipp/talipp/ohlcv"
+)
+
+// Pattern identifies a candlestick pattern
+type Pattern int
+
+const (
+	// PatternDoji is a candle whose open and close are almost equal
+	PatternDoji Pattern = iota
+	// PatternHammer is a small body with a long lower shadow after a downtrend
+	PatternHammer
+	// PatternHangingMan is a small body with a long lower shadow after an uptrend
+	PatternHangingMan
+	// PatternInvertedHammer is a small body with a long upper shadow after a downtrend
+	PatternInvertedHammer
+	// PatternShootingStar is a small body with a long upper shadow after an uptrend
+	PatternShootingStar
+	// PatternEngulfing is a body that engulfs the opposite coloured body of the previous candle
+	PatternEngulfing
+	// PatternHarami is a body contained in the opposite coloured body of the previous candle
+	PatternHarami
+	// PatternMorningStar is a long bearish candle, a small body below it and a
+	// bullish candle closing above the middle of the first one
+	PatternMorningStar
+	// PatternEveningStar is a long bullish candle, a small body above it and a
+	// bearish candle closing below the middle of the first one
+	PatternEveningStar
+	// PatternThreeWhiteSoldiers is three long bullish candles, each one opening
+	// within the previous body and closing higher
+	PatternThreeWhiteSoldiers
+	// PatternThreeBlackCrows is three long bearish candles, each one opening
+	// within the previous body and closing lower
+	PatternThreeBlackCrows
+)
+
+// String returns the name of the pattern
+func (p Pattern) String() string {
+	switch p {
+	case PatternDoji:
+		return "Doji"
+	case PatternHammer:
+		return "Hammer"
+	case PatternHangingMan:
+		return "HangingMan"
+	case PatternInvertedHammer:
+		return "InvertedHammer"
+	case PatternShootingStar:
+		return "ShootingStar"
+	case PatternEngulfing:
+		return "Engulfing"
+	case PatternHarami:
+		return "Harami"
+	case PatternMorningStar:
+		return "MorningStar"
+	case PatternEveningStar:
+		return "EveningStar"
+	case PatternThreeWhiteSoldiers:
+		return "ThreeWhiteSoldiers"
+	case PatternThreeBlackCrows:
+		return "ThreeBlackCrows"
+	default:
+		return fmt.Sprintf("Pattern(%d)", int(p))
+	}
+}
+
+// Direction is the market direction a pattern points to
+type Direction int
+
+const (
+	// Bearish patterns point to falling prices
+	Bearish Direction = -1
+	// Neutral patterns signal indecision
+	Neutral Direction = 0
+	// Bullish patterns point to rising prices
+	Bullish Direction = 1
+)
+
+// String returns the name of the direction
+func (d Direction) String() string {
+	switch d {
+	case Bearish:
+		return "bearish"
+	case Neutral:
+		return "neutral"
+	case Bullish:
+		return "bullish"
+	default:
+		return fmt.Sprintf("Direction(%d)", int(d))
+	}
+}
+
+// Signal is a pattern detected on a bar
+type Signal struct {
+	// Index is the index of the bar completing the pattern, counting from 0 in
+	// the order candles were added to the detector
+	Index int
+	// Timestamp is the timestamp of the bar completing the pattern
+	Timestamp time.Time
+	// Pattern is the detected pattern
+	Pattern Pattern
+	// Direction is the direction the pattern points to
+	Direction Direction
+}
+
+// String returns a readable description of the signal
+func (s Signal) String() string {
+	return fmt.Sprintf("%s %s at bar %d", s.Direction, s.Pattern, s.Index)
+}
+
+// body returns the size of the real body of a candle
+func body(c *ohlcv.OHLCV) float64 {
+	return math.Abs(c.Close - c.Open)
+}
+
+// candleRange returns the distance between the high and the low of a candle
+func candleRange(c *ohlcv.OHLCV) float64 {
+	return c.High - c.Low
+}
+
+// bodyTop returns the upper end of the real body of a candle
+func bodyTop(c *ohlcv.OHLCV) float64 {
+	return math.Max(c.Open, c.Close)
+}
+
+// bodyBottom returns the lower end of the real body of a candle
+func bodyBottom(c *ohlcv.OHLCV) float64 {
+	return math.Min(c.Open, c.Close)
+}
+
+// upperShadow returns the length of the upper shadow of a candle
+func upperShadow(c *ohlcv.OHLCV) float64 {
+	return c.High - bodyTop(c)
+}
+
+// lowerShadow returns the length of the lower shadow of a candle
+func lowerShadow(c *ohlcv.OHLCV) float64 {
+	return bodyBottom(c) - c.Low
+}
+
+// isBullish returns whether a candle closed above its open
+func isBullish(c *ohlcv.OHLCV) bool {
+	return c.Close > c.Open
+}
+
+// isBearish returns whether a candle closed below its open
+func isBearish(c *ohlcv.OHLCV) bool {
+	return c.Close < c.Open
+}
@@ -0,0 +1,221 @@
+package patterns
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/revanthstrakz/gotalipp/talipp/indicators"
+	"github.com/revanthstrakz/gotalipp/talipp/ohlcv"
+	"github.com/stretchr/testify/assert"
+)
+
+// candleStart is the timestamp of the first handcrafted candle
+var candleStart = time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC)
+
+// candles builds handcrafted candles from {open, high, low, close} values, one minute apart
+func candles(values ...[4]float64) []*ohlcv.OHLCV {
+	result := make([]*ohlcv.OHLCV, len(values))
+	for i, v := range values {
+		result[i] = ohlcv.NewOHLCV(candleStart.Add(time.Duration(i)*time.Minute), v[0], v[1], v[2], v[3], 1000)
+	}
+	return result
+}
+
+// trendCandles builds n candles of one point each, falling when direction is
+// Bearish and rising when it is Bullish, ending with a close at last
+func trendCandles(direction Direction, n int, last float64) [][4]float64 {
+	result := make([][4]float64, n)
+	for i := 0; i < n; i++ {
+		close := last - float64(direction)*float64(n-1-i)
+		open := close - float64(direction)
+		result[i] = [4]float64{open, math.Max(open, close) + 0.5, math.Min(open, close) - 0.5, close}
+	}
+	return result
+}
+
+// lastSignals feeds the candles to the detector and returns the signals of the last one
+func lastSignals(d *Detector, values ...[4]float64) []Signal {
+	var signals []Signal
+	for _, candle := range candles(values...) {
+		signals = d.AddCandle(candle)
+	}
+	return signals
+}
+
+// hasSignal returns whether the signals contain the pattern with the direction
+func hasSignal(signals []Signal, pattern Pattern, direction Direction) bool {
+	for _, s := range signals {
+		if s.Pattern == pattern && s.Direction == direction {
+			return true
+		}
+	}
+	return false
+}
+
+func TestSingleCandlePatterns(t *testing.T) {
+	t.Run("Doji", func(t *testing.T) {
+		signals := lastSignals(NewDetector(WithoutTrend()), [4]float64{100, 101, 99, 100.05})
+		assert.True(t, hasSignal(signals, PatternDoji, Neutral))
+		assert.Equal(t, 0, signals[0].Index)
+		assert.Equal(t, candleStart, signals[0].Timestamp)
+
+		// A tighter threshold rejects the same candle
+		signals = lastSignals(NewDetector(WithoutTrend(), WithDojiBodyRatio(0.01)), [4]float64{100, 101, 99, 100.05})
+		assert.False(t, hasSignal(signals, PatternDoji, Neutral))
+
+		signals = lastSignals(NewDetector(WithoutTrend()), [4]float64{100, 101, 99, 100.8})
+		assert.Empty(t, signals)
+	})
+
+	hammer := [4]float64{100, 100.55, 98, 100.5}
+	invertedHammer := [4]float64{100, 102.5, 99.95, 100.5}
+
+	t.Run("Hammer and hanging man", func(t *testing.T) {
+		down := append(trendCandles(Bearish, 5, 100), hammer)
+		signals := lastSignals(NewDetector(WithTrend(indicators.MATypeSMA, 3)), down...)
+		assert.Equal(t, []Signal{{Index: 5, Timestamp: candleStart.Add(5 * time.Minute), Pattern: PatternHammer, Direction: Bullish}}, signals)
+
+		up := append(trendCandles(Bullish, 5, 100), hammer)
+		signals = lastSignals(NewDetector(WithTrend(indicators.MATypeSMA, 3)), up...)
+		assert.True(t, hasSignal(signals, PatternHangingMan, Bearish))
+		assert.False(t, hasSignal(signals, PatternHammer, Bullish))
+
+		// The long shadow must be at least three times the body
+		signals = lastSignals(NewDetector(WithTrend(indicators.MATypeSMA, 3), WithShadowRatios(6, 0.1)), down...)
+		assert.Empty(t, signals)
+	})
+
+	t.Run("Inverted hammer and shooting star", func(t *testing.T) {
+		signals := lastSignals(NewDetector(WithTrend(indicators.MATypeSMA, 3)), append(trendCandles(Bearish, 5, 100), invertedHammer)...)
+		assert.True(t, hasSignal(signals, PatternInvertedHammer, Bullish))
+
+		signals = lastSignals(NewDetector(WithTrend(indicators.MATypeSMA, 3)), append(trendCandles(Bullish, 5, 100), invertedHammer)...)
+		assert.True(t, hasSignal(signals, PatternShootingStar, Bearish))
+	})
+
+	t.Run("No reversal before the trend is known", func(t *testing.T) {
+		signals := lastSignals(NewDetector(), hammer)
+		assert.Empty(t, signals)
+
+		// Without trend context the shape alone is enough
+		signals = lastSignals(NewDetector(WithoutTrend()), hammer)
+		assert.True(t, hasSignal(signals, PatternHammer, Bullish))
+		signals = lastSignals(NewDetector(WithoutTrend()), invertedHammer)
+		assert.True(t, hasSignal(signals, PatternInvertedHammer, Bullish))
+	})
+}
+
+func TestMultiCandlePatterns(t *testing.T) {
+	t.Run("Engulfing", func(t *testing.T) {
+		bullish := append(trendCandles(Bearish, 5, 101), [4]float64{101, 101.2, 99.9, 100}, [4]float64{99.8, 101.7, 99.7, 101.5})
+		signals := lastSignals(NewDetector(WithTrend(indicators.MATypeSMA, 3)), bullish...)
+		assert.Equal(t, []Signal{{Index: 6, Timestamp: candleStart.Add(6 * time.Minute), Pattern: PatternEngulfing, Direction: Bullish}}, signals)
+
+		// The same candles after an uptrend do not reverse anything
+		againstTrend := append(trendCandles(Bullish, 5, 101), [4]float64{101, 101.2, 99.9, 100}, [4]float64{99.8, 101.7, 99.7, 101.5})
+		signals = lastSignals(NewDetector(WithTrend(indicators.MATypeSMA, 3)), againstTrend...)
+		assert.False(t, hasSignal(signals, PatternEngulfing, Bullish))
+
+		bearish := append(trendCandles(Bullish, 5, 100), [4]float64{100, 101.1, 99.9, 101}, [4]float64{101.2, 101.3, 99.3, 99.5})
+		signals = lastSignals(NewDetector(WithTrend(indicators.MATypeSMA, 3)), bearish...)
+		assert.True(t, hasSignal(signals, PatternEngulfing, Bearish))
+	})
+
+	t.Run("Harami", func(t *testing.T) {
+		signals := lastSignals(NewDetector(WithoutTrend()), [4]float64{104, 104.2, 99.8, 100}, [4]float64{101, 102.2, 100.8, 102})
+		assert.True(t, hasSignal(signals, PatternHarami, Bullish))
+
+		signals = lastSignals(NewDetector(WithoutTrend()), [4]float64{100, 104.2, 99.8, 104}, [4]float64{103, 103.2, 101.8, 102})
+		assert.True(t, hasSignal(signals, PatternHarami, Bearish))
+
+		// The first body must be long
+		signals = lastSignals(NewDetector(WithoutTrend(), WithLongBodyRatio(0.95)), [4]float64{104, 104.2, 99.8, 100}, [4]float64{101, 102.2, 100.8, 102})
+		assert.False(t, hasSignal(signals, PatternHarami, Bullish))
+	})
+
+	t.Run("Morning and evening star", func(t *testing.T) {
+		morning := [][4]float64{{105, 105.2, 99.8, 100}, {99.5, 99.9, 98.8, 99.2}, {99.6, 103.2, 99.5, 103}}
+		signals := lastSignals(NewDetector(WithoutTrend()), morning...)
+		assert.True(t, hasSignal(signals, PatternMorningStar, Bullish))
+
+		// A star body larger than the threshold is not a star
+		signals = lastSignals(NewDetector(WithoutTrend(), WithStarBodyRatio(0.05)), morning...)
+		assert.False(t, hasSignal(signals, PatternMorningStar, Bullish))
+
+		evening := [][4]float64{{100, 105.2, 99.8, 105}, {105.5, 106.2, 105.1, 105.8}, {105.4, 105.5, 101.8, 102}}
+		signals = lastSignals(NewDetector(WithoutTrend()), evening...)
+		assert.True(t, hasSignal(signals, PatternEveningStar, Bearish))
+
+		// Evening star after a downtrend is not a reversal
+		signals = lastSignals(NewDetector(WithTrend(indicators.MATypeSMA, 3)), append(trendCandles(Bearish, 5, 100), evening...)...)
+		assert.False(t, hasSignal(signals, PatternEveningStar, Bearish))
+	})
+
+	t.Run("Three white soldiers and three black crows", func(t *testing.T) {
+		soldiers := [][4]float64{{100, 102.1, 99.9, 102}, {101, 103.6, 100.9, 103.5}, {102.5, 105.1, 102.4, 105}}
+		signals := lastSignals(NewDetector(WithoutTrend()), soldiers...)
+		assert.True(t, hasSignal(signals, PatternThreeWhiteSoldiers, Bullish))
+
+		signals = lastSignals(NewDetector(WithTrend(indicators.MATypeSMA, 3)), append(trendCandles(Bearish, 5, 100), soldiers...)...)
+		assert.True(t, hasSignal(signals, PatternThreeWhiteSoldiers, Bullish))
+
+		crows := [][4]float64{{105, 105.1, 102.9, 103}, {104, 104.1, 101.4, 101.5}, {102.5, 102.6, 99.9, 100}}
+		signals = lastSignals(NewDetector(WithoutTrend()), crows...)
+		assert.True(t, hasSignal(signals, PatternThreeBlackCrows, Bearish))
+
+		// Closing lower on the third candle breaks the soldiers
+		broken := [][4]float64{{100, 102.1, 99.9, 102}, {101, 103.6, 100.9, 103.5}, {102.5, 103.5, 102, 103.4}}
+		signals = lastSignals(NewDetector(WithoutTrend()), broken...)
+		assert.False(t, hasSignal(signals, PatternThreeWhiteSoldiers, Bullish))
+	})
+}
+
+func TestDetectorStream(t *testing.T) {
+	values := append(trendCandles(Bearish, 5, 101), [4]float64{101, 101.2, 99.9, 100}, [4]float64{99.8, 101.7, 99.7, 101.5})
+	values = append(values, [4]float64{101.5, 102.5, 100.5, 101.52})
+
+	stream := ohlcv.NewStream()
+	d := NewDetector(WithTrend(indicators.MATypeSMA, 3))
+	live := make([]Signal, 0)
+	stream.OnUpdate(func(candle *ohlcv.OHLCV) {
+		live = append(live, d.AddCandle(candle)...)
+	})
+	for _, candle := range candles(values...) {
+		stream.Add(candle)
+	}
+
+	batch := Detect(stream.Data, WithTrend(indicators.MATypeSMA, 3))
+	assert.Equal(t, live, batch)
+	assert.Equal(t, live, d.GetSignals())
+	assert.Equal(t, 2, len(batch))
+	assert.Equal(t, "bullish Engulfing at bar 6", batch[0].String())
+	assert.Equal(t, "neutral Doji at bar 7", batch[1].String())
+
+	d.Reset()
+	assert.Empty(t, d.GetSignals())
+	for _, candle := range stream.Data {
+		d.AddCandle(candle)
+	}
+	assert.Equal(t, batch, d.GetSignals())
+}
+
+func TestDetectorAttach(t *testing.T) {
+	values := append(trendCandles(Bearish, 5, 101), [4]float64{101, 101.2, 99.9, 100}, [4]float64{99.8, 101.7, 99.7, 101.5})
+	values = append(values, [4]float64{101.5, 102.5, 100.5, 101.52})
+
+	stream := ohlcv.NewStream()
+	d := NewDetector(WithTrend(indicators.MATypeSMA, 3))
+	d.Attach(stream)
+	live := make([]Signal, 0)
+	d.OnSignal(func(signal Signal) {
+		live = append(live, signal)
+	})
+	for _, candle := range candles(values...) {
+		stream.Add(candle)
+	}
+
+	assert.Equal(t, 2, len(live))
+	assert.Equal(t, d.GetSignals(), live)
+	assert.Equal(t, Detect(stream.Data, WithTrend(indicators.MATypeSMA, 3)), live)
+}
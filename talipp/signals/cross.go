@@ -0,0 +1,71 @@
+package signals
+
+import "math"
+
+// Cross detects a series crossing another series or a constant level.
+//
+// A cross is reported on the first bar the series is strictly on the other
+// side of its reference. Bars where both are equal do not count as a side, so
+// touching the reference and turning back is not a cross. A NaN value, e.g.
+// from an indicator that is still warming up, clears the previous side.
+type Cross struct {
+	events
+	level float64
+	side  int
+}
+
+// NewCross creates a new Cross watching two series, added with AddPairValue.
+// Values added with AddValue are compared to 0, e.g. for a MACD histogram.
+func NewCross() *Cross {
+	return NewCrossLevel(0)
+}
+
+// NewCrossLevel creates a new Cross watching a series against a constant level
+// level: the value whose crossings are reported
+func NewCrossLevel(level float64) *Cross {
+	return &Cross{
+		events: newEvents(),
+		level:  level,
+	}
+}
+
+// AddValue adds the next value of the series and compares it to the level
+func (c *Cross) AddValue(value float64) []Event {
+	return c.AddPairValue(value, c.level)
+}
+
+// AddPairValue adds the next values of the series and its reference and
+// returns the events of this bar
+func (c *Cross) AddPairValue(value, reference float64) []Event {
+	c.bars++
+	if math.IsNaN(value) || math.IsNaN(reference) {
+		c.side = 0
+		return nil
+	}
+
+	side := 0
+	if value > reference {
+		side = 1
+	} else if value < reference {
+		side = -1
+	}
+	if side == 0 {
+		return nil
+	}
+
+	previous := c.side
+	c.side = side
+	switch {
+	case previous < 0 && side > 0:
+		return []Event{c.emit(CrossAbove, value, reference)}
+	case previous > 0 && side < 0:
+		return []Event{c.emit(CrossBelow, value, reference)}
+	}
+	return nil
+}
+
+// Reset clears all state and events
+func (c *Cross) Reset() {
+	c.resetEvents()
+	c.side = 0
+}
@@ -0,0 +1,152 @@
+// Package signals detects crossover and threshold events on indicator
+// outputs, either incrementally as values arrive or in batch over slices
+package signals
+
+import (
+	"fmt"
+	"math"
+)
+
+// EventType identifies the kind of a signal event
+type EventType int
+
+const (
+	// CrossAbove is emitted when a series moves from below to above its reference
+	CrossAbove EventType = iota
+	// CrossBelow is emitted when a series moves from above to below its reference
+	CrossBelow
+	// EnterZone is emitted when a series moves into a zone
+	EnterZone
+	// ExitZone is emitted when a series moves out of a zone
+	ExitZone
+)
+
+// String returns the name of the event type
+func (t EventType) String() string {
+	switch t {
+	case CrossAbove:
+		return "CrossAbove"
+	case CrossBelow:
+		return "CrossBelow"
+	case EnterZone:
+		return "EnterZone"
+	case ExitZone:
+		return "ExitZone"
+	default:
+		return fmt.Sprintf("EventType(%d)", int(t))
+	}
+}
+
+// Event represents a signal event on a bar
+type Event struct {
+	Type EventType
+	// Index is the bar of the event, counted from 0 in the order values are added
+	Index int
+	// Value is the value of the watched series on the bar
+	Value float64
+	// Reference is the value crossed: the other series, the level or the zone bound
+	Reference float64
+}
+
+// String returns a short description of the event, e.g. "CrossAbove 30 at bar 12"
+func (e Event) String() string {
+	return fmt.Sprintf("%s %g at bar %d", e.Type, e.Reference, e.Index)
+}
+
+// Watcher is implemented by every event detector of this package
+type Watcher interface {
+	// AddValue adds the next value of the watched series and returns the
+	// events of this bar
+	AddValue(value float64) []Event
+	// OnEvent sets a callback to be called with every new event
+	OnEvent(callback func(Event))
+	// GetEvents returns all events detected so far
+	GetEvents() []Event
+	// Reset clears all state and events
+	Reset()
+}
+
+// events holds the events and bar count shared by all watchers
+type events struct {
+	bars    int
+	events  []Event
+	onEvent func(Event)
+}
+
+// newEvents creates a new events with no events
+func newEvents() events {
+	return events{events: make([]Event, 0)}
+}
+
+// emit records an event on the current bar
+func (e *events) emit(eventType EventType, value, reference float64) Event {
+	event := Event{Type: eventType, Index: e.bars - 1, Value: value, Reference: reference}
+	e.events = append(e.events, event)
+	if e.onEvent != nil {
+		e.onEvent(event)
+	}
+	return event
+}
+
+// OnEvent sets a callback to be called with every new event
+func (e *events) OnEvent(callback func(Event)) {
+	e.onEvent = callback
+}
+
+// GetEvents returns all events detected so far
+func (e *events) GetEvents() []Event {
+	result := make([]Event, len(e.events))
+	copy(result, e.events)
+	return result
+}
+
+// resetEvents clears the events and the bar count
+func (e *events) resetEvents() {
+	e.bars = 0
+	e.events = make([]Event, 0)
+}
+
+// Crossovers returns the events of series crossing reference. Indicator
+// outputs are shorter than their input by the warm-up period, so the two
+// slices are aligned on their last values and event indexes refer to the
+// longer slice.
+func Crossovers(series, reference []float64) []Event {
+	cross := NewCross()
+	length := len(series)
+	if len(reference) > length {
+		length = len(reference)
+	}
+	for i := 0; i < length; i++ {
+		cross.AddPairValue(alignedAt(series, i, length), alignedAt(reference, i, length))
+	}
+	return cross.GetEvents()
+}
+
+// LevelCrossings returns the events of series crossing a constant level
+func LevelCrossings(series []float64, level float64) []Event {
+	return run(NewCrossLevel(level), series)
+}
+
+// ZoneEvents returns the events of series entering and leaving the zone
+// between lower and upper
+func ZoneEvents(series []float64, lower, upper float64) []Event {
+	return run(NewZone(lower, upper), series)
+}
+
+// run adds all values to a watcher and returns its events
+func run(watcher Watcher, series []float64) []Event {
+	for _, value := range series {
+		watcher.AddValue(value)
+	}
+	return watcher.GetEvents()
+}
+
+// alignedAt returns the value of series at index i of a right-aligned slice
+// of the given length, or NaN before the series starts
+func alignedAt(series []float64, i, length int) float64 {
+	j := i - (length - len(series))
+	if j < 0 {
+		return math.NaN()
+	}
+	return series[j]
+}
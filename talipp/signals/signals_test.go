@@ -0,0 +1,162 @@
+package signals
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/revanthstrakz/gotalipp/talipp/indicators"
+	"github.com/revanthstrakz/gotalipp/talipp/ohlcv"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCross(t *testing.T) {
+	t.Run("Two series", func(t *testing.T) {
+		cross := NewCross()
+		received := make([]Event, 0)
+		cross.OnEvent(func(event Event) {
+			received = append(received, event)
+		})
+
+		series := []float64{1, 2, 3, 3, 2, 1, 2}
+		reference := []float64{2, 2, 2, 3, 3, 3, 1}
+		perBar := make([]int, 0)
+		for i := range series {
+			perBar = append(perBar, len(cross.AddPairValue(series[i], reference[i])))
+		}
+
+		expected := []Event{
+			{Type: CrossAbove, Index: 2, Value: 3, Reference: 2},
+			{Type: CrossBelow, Index: 4, Value: 2, Reference: 3},
+			{Type: CrossAbove, Index: 6, Value: 2, Reference: 1},
+		}
+		assert.Equal(t, expected, cross.GetEvents())
+		assert.Equal(t, expected, received)
+		assert.Equal(t, []int{0, 0, 1, 0, 1, 0, 1}, perBar)
+		assert.Equal(t, "CrossAbove 2 at bar 2", expected[0].String())
+
+		cross.Reset()
+		assert.Empty(t, cross.GetEvents())
+		assert.Empty(t, cross.AddPairValue(5, 1))
+	})
+
+	t.Run("Touch is not a cross", func(t *testing.T) {
+		assert.Empty(t, LevelCrossings([]float64{40, 30, 35, 30, 31}, 30))
+		assert.Equal(t, []Event{{Type: CrossBelow, Index: 2, Value: 29, Reference: 30}},
+			LevelCrossings([]float64{40, 30, 29}, 30))
+	})
+
+	t.Run("NaN clears the side", func(t *testing.T) {
+		assert.Empty(t, LevelCrossings([]float64{-1, math.NaN(), 1}, 0))
+		assert.Len(t, LevelCrossings([]float64{math.NaN(), -1, 1}, 0), 1)
+	})
+
+	t.Run("Zero line", func(t *testing.T) {
+		cross := NewCross()
+		for _, value := range []float64{-0.5, 0.2, -0.1} {
+			cross.AddValue(value)
+		}
+		events := cross.GetEvents()
+		if assert.Len(t, events, 2) {
+			assert.Equal(t, CrossAbove, events[0].Type)
+			assert.Equal(t, CrossBelow, events[1].Type)
+		}
+	})
+
+	t.Run("Batch alignment", func(t *testing.T) {
+		closes := []float64{10, 11, 12, 11, 10, 9, 10, 12, 13}
+		sma := indicators.NewSMA(3)
+		for _, value := range closes {
+			sma.AddValue(value)
+		}
+		// SMA(3): 11, 11.33, 11, 10, 9.67, 10.33, 11.67
+		events := Crossovers(closes, sma.GetOutput())
+		expected := []EventType{CrossBelow, CrossAbove}
+		if assert.Len(t, events, len(expected)) {
+			assert.Equal(t, expected[0], events[0].Type)
+			assert.Equal(t, 3, events[0].Index)
+			assert.Equal(t, expected[1], events[1].Type)
+			assert.Equal(t, 6, events[1].Index)
+		}
+		// The order of the slices does not change the indexes
+		assert.Equal(t, 3, Crossovers(sma.GetOutput(), closes)[0].Index)
+	})
+
+	t.Run("Streaming", func(t *testing.T) {
+		stream := ohlcv.NewStream()
+		macd := indicators.NewMACD(3, 6, 3)
+		cross := NewCross()
+		stream.Subscribe(func(candle *ohlcv.OHLCV) {
+			macd.AddValue(candle.Close)
+			output := macd.GetMACDOutput()
+			if len(output) == 0 {
+				return
+			}
+			last := output[len(output)-1]
+			cross.AddPairValue(last.MACD, last.Signal)
+		})
+
+		closes := []float64{10, 11, 12, 13, 14, 15, 14, 13, 12, 11, 10, 11, 12, 13, 14}
+		start := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+		for i, value := range closes {
+			stream.Add(ohlcv.NewOHLCV(start.Add(time.Duration(i)*time.Hour), value, value, value, value, 1))
+		}
+
+		output := macd.GetMACDOutput()
+		macdLine := make([]float64, len(output))
+		signalLine := make([]float64, len(output))
+		for i, o := range output {
+			macdLine[i] = o.MACD
+			signalLine[i] = o.Signal
+		}
+		assert.Equal(t, Crossovers(macdLine, signalLine), cross.GetEvents())
+		assert.NotEmpty(t, cross.GetEvents())
+	})
+}
+
+func TestZone(t *testing.T) {
+	t.Run("Two-sided", func(t *testing.T) {
+		zone := NewZone(30, 70)
+		series := []float64{20, 35, 50, 75, 70, 90, 10, 25, 30}
+		for _, value := range series {
+			zone.AddValue(value)
+		}
+
+		expected := []Event{
+			{Type: EnterZone, Index: 1, Value: 35, Reference: 30},
+			{Type: ExitZone, Index: 3, Value: 75, Reference: 70},
+			{Type: EnterZone, Index: 4, Value: 70, Reference: 70},
+			{Type: ExitZone, Index: 5, Value: 90, Reference: 70},
+			{Type: EnterZone, Index: 8, Value: 30, Reference: 30},
+		}
+		assert.Equal(t, expected, zone.GetEvents())
+		assert.Equal(t, expected, ZoneEvents(series, 30, 70))
+		assert.True(t, zone.IsInside())
+
+		zone.Reset()
+		assert.False(t, zone.IsInside())
+		assert.Empty(t, zone.GetEvents())
+	})
+
+	t.Run("One-sided", func(t *testing.T) {
+		events := ZoneEvents([]float64{45, 32, 28, 25, 31, 29}, math.Inf(-1), 30)
+		expected := []EventType{EnterZone, ExitZone, EnterZone}
+		if assert.Len(t, events, len(expected)) {
+			for i, eventType := range expected {
+				assert.Equal(t, eventType, events[i].Type)
+				assert.Equal(t, 30.0, events[i].Reference)
+			}
+		}
+	})
+
+	t.Run("Invalid bounds", func(t *testing.T) {
+		assert.Panics(t, func() { NewZone(70, 30) })
+	})
+
+	t.Run("Watcher", func(t *testing.T) {
+		var _ Watcher = NewCross()
+		var _ Watcher = NewZone(0, 1)
+		assert.Equal(t, "ExitZone", ExitZone.String())
+		assert.Equal(t, "EventType(9)", EventType(9).String())
+	})
+}
@@ -0,0 +1,78 @@
+package signals
+
+import "math"
+
+// Zone detects a series entering and leaving the zone between a lower and an
+// upper bound, both inclusive. Use an infinite bound for one-sided zones, e.g.
+// NewZone(math.Inf(-1), 30) for an oversold RSI.
+//
+// The reference of an event is the bound the series crossed. A series jumping
+// over the whole zone in one bar produces no event. A NaN value clears the
+// previous state, so the next value does not produce an event.
+type Zone struct {
+	events
+	lower  float64
+	upper  float64
+	known  bool
+	inside bool
+	last   float64
+}
+
+// NewZone creates a new Zone
+// lower: the lower bound of the zone
+// upper: the upper bound of the zone
+func NewZone(lower, upper float64) *Zone {
+	if math.IsNaN(lower) || math.IsNaN(upper) || lower > upper {
+		panic("Zone lower bound must not be greater than upper bound")
+	}
+
+	return &Zone{
+		events: newEvents(),
+		lower:  lower,
+		upper:  upper,
+	}
+}
+
+// AddValue adds the next value of the series and returns the events of this bar
+func (z *Zone) AddValue(value float64) []Event {
+	z.bars++
+	if math.IsNaN(value) {
+		z.known = false
+		return nil
+	}
+
+	inside := value >= z.lower && value <= z.upper
+	wasKnown, wasInside, previous := z.known, z.inside, z.last
+	z.known, z.inside, z.last = true, inside, value
+	if !wasKnown || inside == wasInside {
+		return nil
+	}
+
+	if inside {
+		// Entered through the bound on the side the series came from
+		bound := z.lower
+		if previous > z.upper {
+			bound = z.upper
+		}
+		return []Event{z.emit(EnterZone, value, bound)}
+	}
+
+	bound := z.lower
+	if value > z.upper {
+		bound = z.upper
+	}
+	return []Event{z.emit(ExitZone, value, bound)}
+}
+
+// IsInside returns whether the latest value is inside the zone
+func (z *Zone) IsInside() bool {
+	return z.known && z.inside
+}
+
+// Reset clears all state and events
+func (z *Zone) Reset() {
+	z.resetEvents()
+	z.known = false
+	z.inside = false
+	z.last = 0
+}
@@ -0,0 +1,56 @@
+package transform
+
+import (
+	"math"
+
+	"github.com/revanthstrakz/gotalipp/talipp/ohlcv"
+)
+
+// HeikinAshi transforms candles into Heikin-Ashi candles, one for each source
+// candle, with the same timestamp and volume
+type HeikinAshi struct {
+	output
+	prevOpen      float64
+	prevClose     float64
+	firstValueSet bool
+}
+
+// NewHeikinAshi creates a new Heikin-Ashi transform
+func NewHeikinAshi() *HeikinAshi {
+	return &HeikinAshi{
+		output: newOutput(),
+	}
+}
+
+// AddCandle adds a new source candle to the Heikin-Ashi transform
+func (ha *HeikinAshi) AddCandle(candle *ohlcv.OHLCV) {
+	haClose := (candle.Open + candle.High + candle.Low + candle.Close) / 4.0
+
+	// The first open is the middle of the first candle body, later ones the
+	// middle of the previous Heikin-Ashi body
+	haOpen := (candle.Open + candle.Close) / 2.0
+	if ha.firstValueSet {
+		haOpen = (ha.prevOpen + ha.prevClose) / 2.0
+	}
+
+	ha.prevOpen = haOpen
+	ha.prevClose = haClose
+	ha.firstValueSet = true
+
+	ha.stream.Add(ohlcv.NewOHLCV(
+		candle.Timestamp,
+		haOpen,
+		math.Max(candle.High, math.Max(haOpen, haClose)),
+		math.Min(candle.Low, math.Min(haOpen, haClose)),
+		haClose,
+		candle.Volume,
+	))
+}
+
+// Reset clears the Heikin-Ashi transform and its output stream
+func (ha *HeikinAshi) Reset() {
+	ha.resetOutput()
+	ha.prevOpen = 0
+	ha.prevClose = 0
+	ha.firstValueSet = false
+}
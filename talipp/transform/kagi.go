@@ -0,0 +1,109 @@
+package transform
+
+import (
+	"github.com/revanthstrakz/gotalipp/talipp/ohlcv"
+)
+
+// Kagi transforms candles into Kagi lines built from the closes. A line keeps
+// going while prices move in its direction and is complete once the close
+// reverses by the reversal amount from the line extreme; the next line starts
+// at that extreme.
+//
+// Each completed line is added to the output stream with its start as Open
+// and its extreme as Close, the timestamp of the candle that reversed it and
+// the volume of the candles it spans.
+type Kagi struct {
+	output
+	reversal      float64
+	percent       bool
+	direction     int
+	start         float64
+	extreme       float64
+	volume        float64
+	firstValueSet bool
+}
+
+// KagiOption configures optional Kagi parameters
+type KagiOption func(*Kagi)
+
+// WithKagiPercent makes the reversal amount a percentage of the line extreme
+// instead of a price distance
+func WithKagiPercent() KagiOption {
+	return func(kagi *Kagi) {
+		kagi.percent = true
+	}
+}
+
+// NewKagi creates a new Kagi transform with the specified reversal amount
+func NewKagi(reversal float64, opts ...KagiOption) *Kagi {
+	if reversal <= 0 {
+		panic("Reversal amount must be greater than 0")
+	}
+
+	kagi := &Kagi{
+		output:   newOutput(),
+		reversal: reversal,
+	}
+	for _, opt := range opts {
+		opt(kagi)
+	}
+
+	return kagi
+}
+
+// reversalAmount returns the price distance reversing a line with the given extreme
+func (kagi *Kagi) reversalAmount(extreme float64) float64 {
+	if kagi.percent {
+		return extreme * kagi.reversal / 100.0
+	}
+	return kagi.reversal
+}
+
+// AddCandle adds a new source candle to the Kagi transform
+func (kagi *Kagi) AddCandle(candle *ohlcv.OHLCV) {
+	close := candle.Close
+	if !kagi.firstValueSet {
+		kagi.start = close
+		kagi.extreme = close
+		kagi.volume = candle.Volume
+		kagi.firstValueSet = true
+		return
+	}
+
+	amount := kagi.reversalAmount(kagi.extreme)
+	switch {
+	case kagi.direction == 0:
+		// The first line starts once prices moved by the reversal amount
+		if close >= kagi.start+amount {
+			kagi.direction = 1
+			kagi.extreme = close
+		} else if close <= kagi.start-amount {
+			kagi.direction = -1
+			kagi.extreme = close
+		}
+
+	case kagi.direction > 0 && close > kagi.extreme,
+		kagi.direction < 0 && close < kagi.extreme:
+		kagi.extreme = close
+
+	case kagi.direction > 0 && close <= kagi.extreme-amount,
+		kagi.direction < 0 && close >= kagi.extreme+amount:
+		kagi.emit(candle.Timestamp, kagi.start, kagi.extreme, kagi.volume)
+		kagi.start = kagi.extreme
+		kagi.extreme = close
+		kagi.direction = -kagi.direction
+		kagi.volume = 0
+	}
+
+	kagi.volume += candle.Volume
+}
+
+// Reset clears the Kagi transform and its output stream
+func (kagi *Kagi) Reset() {
+	kagi.resetOutput()
+	kagi.direction = 0
+	kagi.start = 0
+	kagi.extreme = 0
+	kagi.volume = 0
+	kagi.firstValueSet = false
+}
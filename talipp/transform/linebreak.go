@@ -0,0 +1,97 @@
+package transform
+
+import (
+	"math"
+
+	"github.com/revanthstrakz/gotalipp/talipp/ohlcv"
+)
+
+// LineBreak transforms candles into Line Break lines built from the closes. A
+// new line is drawn when the close goes beyond the last line in its
+// direction; a reversal line needs the close to go beyond the extreme of the
+// last lines lines.
+//
+// Lines carry the timestamp of the candle that drew them and the volume of
+// the candles since the previous line.
+type LineBreak struct {
+	output
+	lines         int
+	history       []*ohlcv.OHLCV
+	base          float64
+	volume        float64
+	firstValueSet bool
+}
+
+// NewLineBreak creates a new Line Break transform with the specified number
+// of lines a reversal has to break (default 3)
+func NewLineBreak(lines int) *LineBreak {
+	if lines <= 0 {
+		panic("Number of lines must be greater than 0")
+	}
+
+	return &LineBreak{
+		output:  newOutput(),
+		lines:   lines,
+		history: make([]*ohlcv.OHLCV, 0, lines),
+	}
+}
+
+// AddCandle adds a new source candle to the Line Break transform
+func (lb *LineBreak) AddCandle(candle *ohlcv.OHLCV) {
+	close := candle.Close
+	lb.volume += candle.Volume
+	if !lb.firstValueSet {
+		lb.base = close
+		lb.firstValueSet = true
+		return
+	}
+
+	if len(lb.history) == 0 {
+		if close != lb.base {
+			lb.addLine(candle, lb.base)
+		}
+		return
+	}
+
+	last := lb.history[len(lb.history)-1]
+	highest, lowest := last.High, last.Low
+	for _, line := range lb.history {
+		highest = math.Max(highest, line.High)
+		lowest = math.Min(lowest, line.Low)
+	}
+
+	if last.Close > last.Open {
+		if close > last.Close {
+			lb.addLine(candle, last.Close)
+		} else if close < lowest {
+			lb.addLine(candle, last.Open)
+		}
+	} else {
+		if close < last.Close {
+			lb.addLine(candle, last.Close)
+		} else if close > highest {
+			lb.addLine(candle, last.Open)
+		}
+	}
+}
+
+// addLine draws a new line from open to the close of the candle
+func (lb *LineBreak) addLine(candle *ohlcv.OHLCV, open float64) {
+	lb.emit(candle.Timestamp, open, candle.Close, lb.volume)
+	lb.volume = 0
+
+	line, _ := lb.stream.GetFromLast(0)
+	lb.history = append(lb.history, line)
+	if len(lb.history) > lb.lines {
+		lb.history = lb.history[1:]
+	}
+}
+
+// Reset clears the Line Break transform and its output stream
+func (lb *LineBreak) Reset() {
+	lb.resetOutput()
+	lb.history = make([]*ohlcv.OHLCV, 0, lb.lines)
+	lb.base = 0
+	lb.volume = 0
+	lb.firstValueSet = false
+}
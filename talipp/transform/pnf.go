@@ -0,0 +1,102 @@
+package transform
+
+import (
+	"math"
+
+	"github.com/revanthstrakz/gotalipp/talipp/ohlcv"
+)
+
+// PointAndFigure transforms candles into Point & Figure columns built from
+// the closes. A column of Xs rises one box at a time and a column of Os
+// falls one box at a time; a new column starts when the close reverses by
+// reversal boxes.
+//
+// Each completed column is added to the output stream with its first box as
+// Open and its last box as Close, so X columns are bullish candles and O
+// columns bearish ones. Columns carry the timestamp of the candle that
+// reversed them and the volume of the candles they span.
+type PointAndFigure struct {
+	output
+	boxSize       float64
+	reversal      int
+	direction     int
+	start         float64
+	end           float64
+	volume        float64
+	firstValueSet bool
+}
+
+// NewPointAndFigure creates a new Point & Figure transform
+// boxSize: the price distance of one box
+// reversal: the number of boxes reversing a column (default 3)
+func NewPointAndFigure(boxSize float64, reversal int) *PointAndFigure {
+	if boxSize <= 0 {
+		panic("Box size must be greater than 0")
+	}
+	if reversal <= 0 {
+		panic("Reversal must be greater than 0")
+	}
+
+	return &PointAndFigure{
+		output:   newOutput(),
+		boxSize:  boxSize,
+		reversal: reversal,
+	}
+}
+
+// AddCandle adds a new source candle to the Point & Figure transform
+func (pnf *PointAndFigure) AddCandle(candle *ohlcv.OHLCV) {
+	close := candle.Close
+	if !pnf.firstValueSet {
+		// Columns are aligned on multiples of the box size
+		pnf.start = math.Floor(close/pnf.boxSize+boxEpsilon) * pnf.boxSize
+		pnf.end = pnf.start
+		pnf.volume = candle.Volume
+		pnf.firstValueSet = true
+		return
+	}
+
+	up := boxes(close-pnf.end, pnf.boxSize)
+	down := boxes(pnf.end-close, pnf.boxSize)
+	switch {
+	case pnf.direction >= 0 && up >= 1:
+		if pnf.direction == 0 {
+			pnf.start += pnf.boxSize
+		}
+		pnf.end += float64(up) * pnf.boxSize
+		pnf.direction = 1
+
+	case pnf.direction <= 0 && down >= 1:
+		if pnf.direction == 0 {
+			pnf.start -= pnf.boxSize
+		}
+		pnf.end -= float64(down) * pnf.boxSize
+		pnf.direction = -1
+
+	case pnf.direction > 0 && down >= pnf.reversal:
+		pnf.emit(candle.Timestamp, pnf.start, pnf.end, pnf.volume)
+		pnf.start = pnf.end - pnf.boxSize
+		pnf.end -= float64(down) * pnf.boxSize
+		pnf.direction = -1
+		pnf.volume = 0
+
+	case pnf.direction < 0 && up >= pnf.reversal:
+		pnf.emit(candle.Timestamp, pnf.start, pnf.end, pnf.volume)
+		pnf.start = pnf.end + pnf.boxSize
+		pnf.end += float64(up) * pnf.boxSize
+		pnf.direction = 1
+		pnf.volume = 0
+	}
+
+	pnf.volume += candle.Volume
+}
+
+// Reset clears the Point & Figure transform and its output stream
+func (pnf *PointAndFigure) Reset() {
+	pnf.resetOutput()
+	pnf.direction = 0
+	pnf.start = 0
+	pnf.end = 0
+	pnf.volume = 0
+	pnf.firstValueSet = false
+}
@@ -0,0 +1,67 @@
+package transform
+
+import (
+	"github.com/revanthstrakz/gotalipp/talipp/ohlcv"
+)
+
+// Renko transforms candles into traditional Renko bricks of a fixed box size
+// built from the closes. A brick in the direction of the last one needs the
+// close to move one box beyond it, a reversal brick needs two boxes.
+//
+// Bricks carry the timestamp of the candle that completed them. The volume of
+// the candles since the previous brick goes to the first brick completed, the
+// other bricks of the same candle have no volume.
+type Renko struct {
+	output
+	boxSize       float64
+	top           float64
+	bottom        float64
+	volume        float64
+	firstValueSet bool
+}
+
+// NewRenko creates a new Renko transform with the specified box size
+func NewRenko(boxSize float64) *Renko {
+	if boxSize <= 0 {
+		panic("Box size must be greater than 0")
+	}
+
+	return &Renko{
+		output:  newOutput(),
+		boxSize: boxSize,
+	}
+}
+
+// AddCandle adds a new source candle to the Renko transform
+func (renko *Renko) AddCandle(candle *ohlcv.OHLCV) {
+	renko.volume += candle.Volume
+	if !renko.firstValueSet {
+		// The first close is the base of the first brick
+		renko.top = candle.Close
+		renko.bottom = candle.Close
+		renko.firstValueSet = true
+		return
+	}
+
+	for candle.Close >= renko.top+renko.boxSize {
+		renko.emit(candle.Timestamp, renko.top, renko.top+renko.boxSize, renko.volume)
+		renko.bottom = renko.top
+		renko.top += renko.boxSize
+		renko.volume = 0
+	}
+	for candle.Close <= renko.bottom-renko.boxSize {
+		renko.emit(candle.Timestamp, renko.bottom, renko.bottom-renko.boxSize, renko.volume)
+		renko.top = renko.bottom
+		renko.bottom -= renko.boxSize
+		renko.volume = 0
+	}
+}
+
+// Reset clears the Renko transform and its output stream
+func (renko *Renko) Reset() {
+	renko.resetOutput()
+	renko.top = 0
+	renko.bottom = 0
+	renko.volume = 0
+	renko.firstValueSet = false
+}
@@ -0,0 +1,62 @@
+// Package transform provides chart transforms, such as Heikin-Ashi or Renko,
+// that turn a stream of candles into a derived stream of transformed candles
+// or bricks on which indicators can be run
+package transform
+
+import (
+	"math"
+	"time"
+
+	"github.com/revanthstrakz/gotalipp/talipp/ohlcv"
+)
+
+// Transformer turns candles into a derived stream. Every transformed candle,
+// brick or line is added to the output stream as soon as it is complete, so
+// indicators can subscribe to the output stream like to any other stream.
+type Transformer interface {
+	// AddCandle adds a new source candle to the transform
+	AddCandle(*ohlcv.OHLCV)
+	// Output returns the derived stream
+	Output() *ohlcv.Stream
+	// Reset clears the transform state and its output stream
+	Reset()
+}
+
+// Attach subscribes the transformer to a source stream so that every candle
+// added to the source is transformed incrementally
+func Attach(source *ohlcv.Stream, transformer Transformer) {
+	source.Subscribe(transformer.AddCandle)
+}
+
+// output holds the derived stream shared by all transformers
+type output struct {
+	stream *ohlcv.Stream
+}
+
+// newOutput creates a new output with an empty stream
+func newOutput() output {
+	return output{stream: ohlcv.NewStream()}
+}
+
+// Output returns the derived stream
+func (o *output) Output() *ohlcv.Stream {
+	return o.stream
+}
+
+// emit adds a brick going from open to close to the derived stream
+func (o *output) emit(timestamp time.Time, open, close, volume float64) {
+	o.stream.Add(ohlcv.NewOHLCV(timestamp, open, math.Max(open, close), math.Min(open, close), close, volume))
+}
+
+// resetOutput clears the derived stream, keeping its subscribers
+func (o *output) resetOutput() {
+	o.stream.Clear()
+}
+
+// boxEpsilon absorbs rounding errors when counting boxes between two prices
+const boxEpsilon = 1e-9
+
+// boxes returns the number of whole boxes of boxSize in distance
+func boxes(distance, boxSize float64) int {
+	return int(math.Floor(distance/boxSize + boxEpsilon))
+}
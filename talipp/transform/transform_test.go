@@ -0,0 +1,158 @@
+package transform
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/revanthstrakz/gotalipp/talipp/indicators"
+	"github.com/revanthstrakz/gotalipp/talipp/ohlcv"
+	"github.com/stretchr/testify/assert"
+)
+
+// candleStart is the timestamp of the first test candle
+var candleStart = time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC)
+
+// closeCandles builds candles one minute apart from closes, each one opening
+// at the previous close, with a volume of 100
+func closeCandles(closes ...float64) []*ohlcv.OHLCV {
+	result := make([]*ohlcv.OHLCV, len(closes))
+	open := closes[0]
+	for i, close := range closes {
+		result[i] = ohlcv.NewOHLCV(candleStart.Add(time.Duration(i)*time.Minute),
+			open, math.Max(open, close)+0.5, math.Min(open, close)-0.5, close, 100)
+		open = close
+	}
+	return result
+}
+
+// brick is the open, close and volume of a transformed candle
+type brick struct {
+	open, close, volume float64
+}
+
+// bricks returns the open, close and volume of the candles of a stream,
+// checking that high and low match the body
+func bricks(t *testing.T, stream *ohlcv.Stream) []brick {
+	result := make([]brick, len(stream.Data))
+	for i, c := range stream.Data {
+		assert.Equal(t, math.Max(c.Open, c.Close), c.High)
+		assert.Equal(t, math.Min(c.Open, c.Close), c.Low)
+		result[i] = brick{c.Open, c.Close, c.Volume}
+	}
+	return result
+}
+
+// run feeds the candles to the transformer
+func run(transformer Transformer, candles []*ohlcv.OHLCV) {
+	for _, candle := range candles {
+		transformer.AddCandle(candle)
+	}
+}
+
+func TestHeikinAshi(t *testing.T) {
+	ha := NewHeikinAshi()
+	run(ha, []*ohlcv.OHLCV{
+		ohlcv.NewOHLCV(candleStart, 100, 102, 99, 101, 10),
+		ohlcv.NewOHLCV(candleStart.Add(time.Minute), 101, 104, 100, 103, 20),
+		ohlcv.NewOHLCV(candleStart.Add(2*time.Minute), 103, 103.5, 101, 101.5, 30),
+	})
+
+	assert.Equal(t, []*ohlcv.OHLCV{
+		ohlcv.NewOHLCV(candleStart, 100.5, 102, 99, 100.5, 10),
+		ohlcv.NewOHLCV(candleStart.Add(time.Minute), 100.5, 104, 100, 102, 20),
+		ohlcv.NewOHLCV(candleStart.Add(2*time.Minute), 101.25, 103.5, 101, 102.25, 30),
+	}, ha.Output().Data)
+}
+
+func TestRenko(t *testing.T) {
+	renko := NewRenko(1)
+	candles := closeCandles(100, 100.5, 101.2, 103.4, 102.6, 101.9, 100.9, 99.7)
+	run(renko, candles)
+
+	assert.Equal(t, []brick{
+		{100, 101, 300}, {101, 102, 100}, {102, 103, 0}, {102, 101, 300}, {101, 100, 100},
+	}, bricks(t, renko.Output()))
+
+	// Bricks carry the timestamp of the candle completing them
+	assert.Equal(t, candles[3].Timestamp, renko.Output().Data[2].Timestamp)
+	assert.Equal(t, candles[7].Timestamp, renko.Output().Data[4].Timestamp)
+
+	assert.Panics(t, func() { NewRenko(0) })
+}
+
+func TestKagi(t *testing.T) {
+	candles := closeCandles(100, 101, 103, 102, 104, 101.5, 100, 101, 102.5)
+
+	kagi := NewKagi(2)
+	run(kagi, candles)
+	assert.Equal(t, []brick{{100, 104, 500}, {104, 100, 300}}, bricks(t, kagi.Output()))
+	assert.Equal(t, candles[5].Timestamp, kagi.Output().Data[0].Timestamp)
+	assert.Equal(t, candles[8].Timestamp, kagi.Output().Data[1].Timestamp)
+
+	// 2.5% of 104 is 2.6, so 101.5 does not reverse the first line but 100 does
+	percent := NewKagi(2.5, WithKagiPercent())
+	run(percent, candles)
+	assert.Equal(t, []brick{{100, 104, 600}, {104, 100, 200}}, bricks(t, percent.Output()))
+	assert.Equal(t, candles[6].Timestamp, percent.Output().Data[0].Timestamp)
+}
+
+func TestPointAndFigure(t *testing.T) {
+	pnf := NewPointAndFigure(1, 3)
+	run(pnf, closeCandles(100.2, 101.5, 103.1, 102.4, 99.9, 98.7, 100.1, 102.2))
+
+	assert.Equal(t, []brick{{101, 103, 400}, {102, 99, 300}}, bricks(t, pnf.Output()))
+
+	// Fractional box sizes count boxes despite rounding errors
+	fractional := NewPointAndFigure(0.1, 3)
+	run(fractional, closeCandles(10.0, 10.3, 10.0))
+	assert.Equal(t, 1, fractional.Output().Size())
+	assert.InDelta(t, 10.1, fractional.Output().Data[0].Open, 1e-9)
+	assert.InDelta(t, 10.3, fractional.Output().Data[0].Close, 1e-9)
+}
+
+func TestLineBreak(t *testing.T) {
+	lb := NewLineBreak(3)
+	run(lb, closeCandles(100, 101, 102, 101.5, 103, 100.5, 99.5, 102.8, 103.5))
+
+	assert.Equal(t, []brick{
+		{100, 101, 200}, {101, 102, 100}, {102, 103, 200}, {102, 99.5, 200}, {102, 103.5, 200},
+	}, bricks(t, lb.Output()))
+}
+
+func TestAttach(t *testing.T) {
+	source := ohlcv.NewStream()
+	renko := NewRenko(1)
+	ha := NewHeikinAshi()
+	Attach(source, renko)
+	Attach(source, ha)
+
+	// The OnUpdate callback keeps working next to the subscribers
+	updates := 0
+	source.OnUpdate(func(*ohlcv.OHLCV) { updates++ })
+
+	// Indicators run on the derived stream
+	sma := indicators.NewSMA(2)
+	renko.Output().Subscribe(func(brick *ohlcv.OHLCV) {
+		sma.AddValue(brick.Close)
+	})
+
+	candles := closeCandles(100, 100.5, 101.2, 103.4, 102.6, 101.9, 100.9, 99.7)
+	for _, candle := range candles {
+		source.Add(candle)
+	}
+
+	assert.Equal(t, len(candles), updates)
+	assert.Equal(t, len(candles), ha.Output().Size())
+	assert.Equal(t, 5, renko.Output().Size())
+	assert.Equal(t, []float64{101.5, 102.5, 102, 100.5}, sma.GetOutput())
+
+	// Reset clears the output but keeps its subscribers
+	renko.Reset()
+	sma.Reset()
+	assert.Equal(t, 0, renko.Output().Size())
+	for _, candle := range candles {
+		renko.AddCandle(candle)
+	}
+	assert.Equal(t, []float64{101.5, 102.5, 102, 100.5}, sma.GetOutput())
+}